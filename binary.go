@@ -0,0 +1,231 @@
+package types
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Бинарный формат DateTime, Date и их Null-вариантов:
+// байт версии, байт флагов, длина шаблона Layout (uvarint), шаблон Layout
+// и, если значение не NULL, время в формате time.Time.MarshalBinary.
+// Методы заменяют одноимённые методы встроенного time.Time, которые
+// теряют Layout и флаг marshalToUTCTimeStamp
+
+// binaryVersion текущая версия бинарного формата
+const binaryVersion byte = 1
+
+// Флаги бинарного формата
+const (
+	binaryFlagTimeStamp byte = 1 << iota
+	binaryFlagNull
+)
+
+// binaryTime содержит поля, сохраняемые в бинарном формате
+type binaryTime struct {
+	time        time.Time
+	layout      string
+	toTimeStamp bool
+	null        bool
+}
+
+// appendBinary добавляет к b бинарное представление bt
+func appendBinary(b []byte, bt binaryTime) ([]byte, error) {
+	var flags byte
+	if bt.toTimeStamp {
+		flags |= binaryFlagTimeStamp
+	}
+	if bt.null {
+		flags |= binaryFlagNull
+	}
+	b = append(b, binaryVersion, flags)
+	b = binary.AppendUvarint(b, uint64(len(bt.layout)))
+	b = append(b, bt.layout...)
+	if bt.null {
+		return b, nil
+	}
+	data, err := bt.time.MarshalBinary()
+	return append(b, data...), err
+}
+
+// unmarshalBinary разбирает бинарное представление data.
+// Время в часовом поясе по умолчанию переводится в defaultLocation
+func unmarshalBinary(data []byte) (binaryTime, error) {
+	var bt binaryTime
+	if len(data) < 2 {
+		return bt, errors.New("Неверный формат бинарных данных: недостаточно данных")
+	}
+	if data[0] != binaryVersion {
+		return bt, fmt.Errorf("Неверный формат бинарных данных: неподдерживаемая версия %d", data[0])
+	}
+	flags := data[1]
+	bt.toTimeStamp = flags&binaryFlagTimeStamp != 0
+	bt.null = flags&binaryFlagNull != 0
+
+	n, size := binary.Uvarint(data[2:])
+	if size <= 0 || n > uint64(len(data)-2-size) {
+		return bt, errors.New("Неверный формат бинарных данных: неверная длина шаблона")
+	}
+	data = data[2+size:]
+	bt.layout = string(data[:n])
+	data = data[n:]
+
+	if bt.null {
+		if len(data) != 0 {
+			return bt, errors.New("Неверный формат бинарных данных: лишние данные после значения NULL")
+		}
+		return bt, nil
+	}
+	if err := bt.time.UnmarshalBinary(data); err != nil {
+		return bt, fmt.Errorf("Неверный формат бинарных данных: %s", err)
+	}
+	t := bt.time.In(defaultLocation)
+	_, offset := bt.time.Zone()
+	if _, defaultOffset := t.Zone(); defaultOffset == offset {
+		bt.time = t
+	}
+	return bt, nil
+}
+
+// AppendBinary реализует интерфейс encoding.BinaryAppender для объекта DateTime
+func (d DateTime) AppendBinary(b []byte) ([]byte, error) {
+	return appendBinary(b, binaryTime{time: d.Time, layout: d.Layout, toTimeStamp: d.marshalToUTCTimeStamp})
+}
+
+// MarshalBinary реализует интерфейс encoding.BinaryMarshaler для объекта DateTime
+// вместе со временем сохраняются Layout и флаг сериализации в timestamp
+func (d DateTime) MarshalBinary() ([]byte, error) {
+	return d.AppendBinary(nil)
+}
+
+// UnmarshalBinary реализует интерфейс encoding.BinaryUnmarshaler для объекта DateTime
+func (d *DateTime) UnmarshalBinary(data []byte) error {
+	bt, err := unmarshalBinary(data)
+	if err != nil {
+		return err
+	}
+	if bt.null {
+		return errors.New("Ошибка UnmarshalBinary: значение NULL не может быть записано в DateTime")
+	}
+	*d = DateTime{Time: bt.time, Layout: bt.layout, marshalToUTCTimeStamp: bt.toTimeStamp}
+	return nil
+}
+
+// GobEncode реализует интерфейс gob.GobEncoder для объекта DateTime
+func (d DateTime) GobEncode() ([]byte, error) {
+	return d.MarshalBinary()
+}
+
+// GobDecode реализует интерфейс gob.GobDecoder для объекта DateTime
+func (d *DateTime) GobDecode(data []byte) error {
+	return d.UnmarshalBinary(data)
+}
+
+// AppendBinary реализует интерфейс encoding.BinaryAppender для объекта Date
+func (d Date) AppendBinary(b []byte) ([]byte, error) {
+	return appendBinary(b, binaryTime{time: d.Time, layout: d.Layout, toTimeStamp: d.marshalToUTCTimeStamp})
+}
+
+// MarshalBinary реализует интерфейс encoding.BinaryMarshaler для объекта Date
+// вместе с датой сохраняются Layout и флаг сериализации в timestamp
+func (d Date) MarshalBinary() ([]byte, error) {
+	return d.AppendBinary(nil)
+}
+
+// UnmarshalBinary реализует интерфейс encoding.BinaryUnmarshaler для объекта Date
+func (d *Date) UnmarshalBinary(data []byte) error {
+	bt, err := unmarshalBinary(data)
+	if err != nil {
+		return err
+	}
+	if bt.null {
+		return errors.New("Ошибка UnmarshalBinary: значение NULL не может быть записано в Date")
+	}
+	*d = Date{Time: bt.time, Layout: bt.layout, marshalToUTCTimeStamp: bt.toTimeStamp}
+	return nil
+}
+
+// GobEncode реализует интерфейс gob.GobEncoder для объекта Date
+func (d Date) GobEncode() ([]byte, error) {
+	return d.MarshalBinary()
+}
+
+// GobDecode реализует интерфейс gob.GobDecoder для объекта Date
+func (d *Date) GobDecode(data []byte) error {
+	return d.UnmarshalBinary(data)
+}
+
+// AppendBinary реализует интерфейс encoding.BinaryAppender для объекта NullDateTime
+func (d NullDateTime) AppendBinary(b []byte) ([]byte, error) {
+	return appendBinary(b, binaryTime{
+		time:        d.Time,
+		layout:      d.Layout,
+		toTimeStamp: d.marshalToUTCTimeStamp,
+		null:        !d.Valid,
+	})
+}
+
+// MarshalBinary реализует интерфейс encoding.BinaryMarshaler для объекта NullDateTime
+// для значения NULL сохраняются только Layout и флаги
+func (d NullDateTime) MarshalBinary() ([]byte, error) {
+	return d.AppendBinary(nil)
+}
+
+// UnmarshalBinary реализует интерфейс encoding.BinaryUnmarshaler для объекта NullDateTime
+func (d *NullDateTime) UnmarshalBinary(data []byte) error {
+	bt, err := unmarshalBinary(data)
+	if err != nil {
+		return err
+	}
+	d.DateTime = DateTime{Time: bt.time, Layout: bt.layout, marshalToUTCTimeStamp: bt.toTimeStamp}
+	d.Valid = !bt.null
+	return nil
+}
+
+// GobEncode реализует интерфейс gob.GobEncoder для объекта NullDateTime
+func (d NullDateTime) GobEncode() ([]byte, error) {
+	return d.MarshalBinary()
+}
+
+// GobDecode реализует интерфейс gob.GobDecoder для объекта NullDateTime
+func (d *NullDateTime) GobDecode(data []byte) error {
+	return d.UnmarshalBinary(data)
+}
+
+// AppendBinary реализует интерфейс encoding.BinaryAppender для объекта NullDate
+func (d NullDate) AppendBinary(b []byte) ([]byte, error) {
+	return appendBinary(b, binaryTime{
+		time:        d.Time,
+		layout:      d.Layout,
+		toTimeStamp: d.marshalToUTCTimeStamp,
+		null:        !d.Valid,
+	})
+}
+
+// MarshalBinary реализует интерфейс encoding.BinaryMarshaler для объекта NullDate
+// для значения NULL сохраняются только Layout и флаги
+func (d NullDate) MarshalBinary() ([]byte, error) {
+	return d.AppendBinary(nil)
+}
+
+// UnmarshalBinary реализует интерфейс encoding.BinaryUnmarshaler для объекта NullDate
+func (d *NullDate) UnmarshalBinary(data []byte) error {
+	bt, err := unmarshalBinary(data)
+	if err != nil {
+		return err
+	}
+	d.Date = Date{Time: bt.time, Layout: bt.layout, marshalToUTCTimeStamp: bt.toTimeStamp}
+	d.Valid = !bt.null
+	return nil
+}
+
+// GobEncode реализует интерфейс gob.GobEncoder для объекта NullDate
+func (d NullDate) GobEncode() ([]byte, error) {
+	return d.MarshalBinary()
+}
+
+// GobDecode реализует интерфейс gob.GobDecoder для объекта NullDate
+func (d *NullDate) GobDecode(data []byte) error {
+	return d.UnmarshalBinary(data)
+}
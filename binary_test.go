@@ -0,0 +1,112 @@
+package types
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+	"time"
+
+	"github.com/mihteh/types/decimal"
+)
+
+func TestDateTimeDateBinary(t *testing.T) {
+	dt, err := StringToDateTime("2017-07-14 15:04:05")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dt.Layout = GraphsDateLayout
+	dt.SetMarshalToUTCTimeStamp(true)
+	data, err := dt.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dt2 DateTime
+	if err := dt2.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !dt2.Equal(dt) || dt2.Layout != dt.Layout || !dt2.marshalToUTCTimeStamp || dt2.Location() != defaultLocation {
+		t.Fatalf("Ожидалось %#v, получено %#v", dt, dt2)
+	}
+
+	d := ToDate(time.Date(2017, 7, 14, 0, 0, 0, 0, time.UTC))
+	data, err = d.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d2 Date
+	if err := d2.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !d2.Time.Equal(d.Time) || d2.Layout != DateLayout || d2.marshalToUTCTimeStamp {
+		t.Fatalf("Ожидалось %#v, получено %#v", d, d2)
+	}
+
+	null := MakeNullDate()
+	null.Layout = GraphsDateLayout
+	data, err = null.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	nd := DateNow().Nullable()
+	if err := nd.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if nd.Valid || nd.Layout != GraphsDateLayout {
+		t.Fatalf("Ожидалось NULL с шаблоном %s, получено %#v", GraphsDateLayout, nd)
+	}
+	if err := d2.UnmarshalBinary(data); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+
+	for _, data := range [][]byte{nil, {binaryVersion}, {2, 0, 0}, {binaryVersion, 0, 10, 'a'}, {binaryVersion, 0, 0, 1}} {
+		if err := dt2.UnmarshalBinary(data); err == nil {
+			t.Fatalf("%v: ожидалась ошибка", data)
+		}
+	}
+}
+
+func TestDateTimeDateGob(t *testing.T) {
+	type cached struct {
+		Created DateTime
+		Day     Date
+		Closed  NullDateTime
+		Paid    NullDate
+		Amount  decimal.Decimal
+	}
+	dt, err := StringToDateTime("2017-07-14 15:04:05")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dt.SetMarshalToUTCTimeStamp(true)
+	day := dt.ConvertToDate()
+	day.Layout = GraphsDateLayout
+	in := cached{
+		Created: dt,
+		Day:     day,
+		Closed:  MakeNullDateTime(),
+		Paid:    day.Nullable(),
+		Amount:  decimal.New(12345, -4),
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	var out cached
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	if !out.Created.Equal(in.Created) || !out.Created.marshalToUTCTimeStamp {
+		t.Fatalf("Ожидалось %#v, получено %#v", in.Created, out.Created)
+	}
+	if s := out.Day.String(); s != "14.07.2017" {
+		t.Fatalf("Ожидалось 14.07.2017, получено %s", s)
+	}
+	if out.Closed.Valid || !out.Paid.Valid || out.Paid.String() != "14.07.2017" {
+		t.Fatalf("Ожидалось %v, %v, получено %v, %v", in.Closed, in.Paid, out.Closed, out.Paid)
+	}
+	if !out.Amount.Equals(in.Amount) || out.Amount.String() != "1.2345" {
+		t.Fatalf("Ожидалось %s, получено %s", in.Amount, out.Amount)
+	}
+}
@@ -0,0 +1,151 @@
+package types
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Типы элементов BSON, используемые для времени
+const (
+	bsonTypeDateTime = 0x09
+	bsonTypeNull     = 0x0a
+)
+
+// BSONDateTime возвращает значение BSON UTC datetime для d:
+// число миллисекунд от начала эпохи Unix. Доли миллисекунды отбрасываются
+func (d DateTime) BSONDateTime() int64 {
+	return d.UnixMilli()
+}
+
+// BSONDateTimeToDateTime формирует объект типа DateTime на основе значения
+// BSON UTC datetime в часовом поясе по умолчанию и с шаблоном DateTimeLayout
+func BSONDateTimeToDateTime(ms int64) DateTime {
+	dt := NewDateTime()
+	dt.setTime(bsonTime(ms))
+	return dt
+}
+
+// BSONDateTime возвращает значение BSON UTC datetime для начала дня d
+func (d Date) BSONDateTime() int64 {
+	return d.UnixMilli()
+}
+
+// BSONDateTimeToDate формирует объект типа Date на основе значения
+// BSON UTC datetime, дата берётся в часовом поясе по умолчанию
+func BSONDateTimeToDate(ms int64) Date {
+	return ToDate(bsonTime(ms))
+}
+
+// bsonTime возвращает время по числу миллисекунд в часовом поясе по умолчанию
+func bsonTime(ms int64) time.Time {
+	return time.UnixMilli(ms).In(defaultLocation)
+}
+
+// readBSONDateTime разбирает значение BSON UTC datetime или null
+func readBSONDateTime(typ byte, data []byte) (ms int64, null bool, err error) {
+	switch {
+	case typ == bsonTypeDateTime && len(data) == 8:
+		return int64(binary.LittleEndian.Uint64(data)), false, nil
+	case typ == bsonTypeNull && len(data) == 0:
+		return 0, true, nil
+	default:
+		return 0, false, fmt.Errorf("Неверный формат BSON: ожидался datetime, получен тип 0x%02x длиной %d", typ, len(data))
+	}
+}
+
+// appendBSONDateTime возвращает значение BSON UTC datetime
+func appendBSONDateTime(ms int64) (byte, []byte, error) {
+	return bsonTypeDateTime, binary.LittleEndian.AppendUint64(nil, uint64(ms)), nil
+}
+
+// MarshalBSONValue преобразует DateTime в BSON UTC datetime
+// Совместим с интерфейсом bson.ValueMarshaler
+func (d DateTime) MarshalBSONValue() (byte, []byte, error) {
+	return appendBSONDateTime(d.BSONDateTime())
+}
+
+// UnmarshalBSONValue разбирает DateTime из BSON UTC datetime
+// Совместим с интерфейсом bson.ValueUnmarshaler
+func (d *DateTime) UnmarshalBSONValue(typ byte, data []byte) error {
+	ms, null, err := readBSONDateTime(typ, data)
+	if err != nil {
+		return err
+	}
+	if null {
+		return errors.New("Ошибка UnmarshalBSONValue: значение null не может быть записано в DateTime")
+	}
+	d.fixLayout()
+	d.setTime(bsonTime(ms))
+	return nil
+}
+
+// MarshalBSONValue преобразует Date в BSON UTC datetime начала дня
+// Совместим с интерфейсом bson.ValueMarshaler
+func (d Date) MarshalBSONValue() (byte, []byte, error) {
+	return appendBSONDateTime(d.BSONDateTime())
+}
+
+// UnmarshalBSONValue разбирает Date из BSON UTC datetime, время отбрасывается
+// Совместим с интерфейсом bson.ValueUnmarshaler
+func (d *Date) UnmarshalBSONValue(typ byte, data []byte) error {
+	ms, null, err := readBSONDateTime(typ, data)
+	if err != nil {
+		return err
+	}
+	if null {
+		return errors.New("Ошибка UnmarshalBSONValue: значение null не может быть записано в Date")
+	}
+	d.fixLayout()
+	d.setTime(BSONDateTimeToDate(ms).Time)
+	return nil
+}
+
+// MarshalBSONValue преобразует NullDateTime в BSON UTC datetime,
+// значение NULL преобразуется в null
+func (d NullDateTime) MarshalBSONValue() (byte, []byte, error) {
+	if !d.Valid {
+		return bsonTypeNull, nil, nil
+	}
+	return d.DateTime.MarshalBSONValue()
+}
+
+// UnmarshalBSONValue разбирает NullDateTime из BSON UTC datetime,
+// null преобразуется в значение NULL
+func (d *NullDateTime) UnmarshalBSONValue(typ byte, data []byte) error {
+	ms, null, err := readBSONDateTime(typ, data)
+	if err != nil {
+		return err
+	}
+	d.Valid = !null
+	if d.Valid {
+		d.fixLayout()
+		d.setTime(bsonTime(ms))
+	}
+	return nil
+}
+
+// MarshalBSONValue преобразует NullDate в BSON UTC datetime,
+// значение NULL преобразуется в null
+func (d NullDate) MarshalBSONValue() (byte, []byte, error) {
+	if !d.Valid {
+		return bsonTypeNull, nil, nil
+	}
+	return d.Date.MarshalBSONValue()
+}
+
+// UnmarshalBSONValue разбирает NullDate из BSON UTC datetime,
+// null преобразуется в значение NULL
+func (d *NullDate) UnmarshalBSONValue(typ byte, data []byte) error {
+	ms, null, err := readBSONDateTime(typ, data)
+	if err != nil {
+		return err
+	}
+	d.Valid = !null
+	if d.Valid {
+		d.fixLayout()
+		d.setTime(BSONDateTimeToDate(ms).Time)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+)
+
+func TestDateTimeBSON(t *testing.T) {
+	// пример из набора тестов BSON спецификаций MongoDB
+	dt := DateTime{Time: time.Date(2012, 12, 24, 12, 15, 30, 501999999, time.UTC)}
+	if ms := dt.BSONDateTime(); ms != 1356351330501 {
+		t.Fatalf("Ожидалось 1356351330501, получено %d", ms)
+	}
+	typ, data, err := dt.MarshalBSONValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := hex.EncodeToString(data); typ != 0x09 || s != "c5d8d6cc3b010000" {
+		t.Fatalf("Ожидалось 0x09 c5d8d6cc3b010000, получено 0x%02x %s", typ, s)
+	}
+	dt2 := DateTime{Layout: GraphsDateLayout}
+	if err := dt2.UnmarshalBSONValue(typ, data); err != nil {
+		t.Fatal(err)
+	}
+	if !dt2.Equal(DateTime{Time: dt.Truncate(time.Millisecond)}) || dt2.Location() != defaultLocation || dt2.Layout != GraphsDateLayout {
+		t.Fatalf("Ожидалось %v, получено %#v", dt.Time, dt2)
+	}
+
+	// время до начала эпохи Unix округляется в меньшую сторону
+	before := DateTime{Time: time.Date(1960, 12, 24, 12, 15, 30, 500500000, time.UTC)}
+	if ms := before.BSONDateTime(); ms != -284643869500 {
+		t.Fatalf("Ожидалось -284643869500, получено %d", ms)
+	}
+	if dt := BSONDateTimeToDateTime(-284643869501); !dt.Equal(DateTime{Time: time.Date(1960, 12, 24, 12, 15, 30, 499000000, time.UTC)}) {
+		t.Fatalf("Ожидалось 1960-12-24 12:15:30.499, получено %v", dt.Time)
+	}
+
+	if err := dt2.UnmarshalBSONValue(0x0a, nil); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+	if err := dt2.UnmarshalBSONValue(0x09, data[:4]); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+	if err := dt2.UnmarshalBSONValue(0x12, data); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+
+	ndt := MakeNullDateTime()
+	if typ, data, err := ndt.MarshalBSONValue(); typ != 0x0a || len(data) != 0 || err != nil {
+		t.Fatalf("Ожидалось 0x0a, получено 0x%02x %x, %v", typ, data, err)
+	}
+	if err := ndt.UnmarshalBSONValue(typ, data); err != nil || !ndt.Valid || !ndt.Equal(dt2) {
+		t.Fatalf("Ожидалось %v, получено %v, %v", dt2, ndt, err)
+	}
+	if err := ndt.UnmarshalBSONValue(0x0a, nil); err != nil || ndt.Valid {
+		t.Fatalf("Ожидалось NULL, получено %v, %v", ndt, err)
+	}
+}
+
+func TestDateBSON(t *testing.T) {
+	d, err := StringToDate("2017-07-14")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ms := d.BSONDateTime(); ms != d.Unix()*1000 {
+		t.Fatalf("Ожидалось %d, получено %d", d.Unix()*1000, ms)
+	}
+	typ, data, err := d.MarshalBSONValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d2 Date
+	if err := d2.UnmarshalBSONValue(typ, data); err != nil {
+		t.Fatal(err)
+	}
+	if !d2.Equal(d) || d2.String() != "2017-07-14" {
+		t.Fatalf("Ожидалось 2017-07-14, получено %v", d2)
+	}
+	if d3 := BSONDateTimeToDate(d.BSONDateTime() + 15*3600*1000); !d3.Equal(d) {
+		t.Fatalf("Ожидалось 2017-07-14, получено %v", d3)
+	}
+	if err := d2.UnmarshalBSONValue(0x0a, nil); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+
+	nd := d.Nullable()
+	typ, data, err = MakeNullDate().MarshalBSONValue()
+	if err != nil || typ != 0x0a {
+		t.Fatalf("Ожидалось 0x0a, получено 0x%02x, %v", typ, err)
+	}
+	if err := nd.UnmarshalBSONValue(typ, data); err != nil || nd.Valid {
+		t.Fatalf("Ожидалось NULL, получено %v, %v", nd, err)
+	}
+	typ, data, _ = d.Nullable().MarshalBSONValue()
+	if err := nd.UnmarshalBSONValue(typ, data); err != nil || !nd.Valid || !nd.Equal(d) {
+		t.Fatalf("Ожидалось %v, получено %v, %v", d, nd, err)
+	}
+}
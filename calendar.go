@@ -0,0 +1,209 @@
+package types
+
+import (
+	"time"
+)
+
+// daysInMonth возвращает количество дней в месяце month года year
+func daysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// weekOffset возвращает количество дней, прошедших от начала недели,
+// начинающейся с дня first, до дня weekday
+func weekOffset(weekday, first time.Weekday) int {
+	return (int(weekday) - int(first) + 7) % 7
+}
+
+// quarterMonth возвращает первый месяц квартала, которому принадлежит месяц month
+func quarterMonth(month time.Month) time.Month {
+	return month - (month-1)%3
+}
+
+// addMonths прибавляет к t месяцы months так, что день месяца не выходит
+// за пределы получившегося месяца: 31 января + 1 месяц = 28 (29) февраля
+func addMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	m := int(month) - 1 + months
+	year += m / 12
+	m %= 12
+	if m < 0 {
+		m += 12
+		year--
+	}
+	month = time.Month(m + 1)
+	if last := daysInMonth(year, month); day > last {
+		day = last
+	}
+	return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// setDate возвращает время t с заданными годом, месяцем, днём, часами, минутами
+// и секундами в часовом поясе t
+func setDate(t time.Time, year int, month time.Month, day, hours, mins, secs int) time.Time {
+	return time.Date(year, month, day, hours, mins, secs, 0, t.Location())
+}
+
+// LastDayOfMonth возвращает номер последнего дня месяца, которому принадлежит дата d
+func (d Date) LastDayOfMonth() int {
+	return daysInMonth(d.Year(), d.Month())
+}
+
+// StartOfWeek возвращает первый день недели, которой принадлежит дата d.
+// Неделя начинается с понедельника
+func (d Date) StartOfWeek() Date {
+	return d.StartOfWeekFrom(time.Monday)
+}
+
+// EndOfWeek возвращает последний день недели, которой принадлежит дата d.
+// Неделя начинается с понедельника
+func (d Date) EndOfWeek() Date {
+	return d.EndOfWeekFrom(time.Monday)
+}
+
+// StartOfWeekFrom возвращает первый день недели, которой принадлежит дата d,
+// если неделя начинается с дня first
+func (d Date) StartOfWeekFrom(first time.Weekday) Date {
+	d.Time = d.Time.AddDate(0, 0, -weekOffset(d.Weekday(), first))
+	return d
+}
+
+// EndOfWeekFrom возвращает последний день недели, которой принадлежит дата d,
+// если неделя начинается с дня first
+func (d Date) EndOfWeekFrom(first time.Weekday) Date {
+	d.Time = d.Time.AddDate(0, 0, 6-weekOffset(d.Weekday(), first))
+	return d
+}
+
+// StartOfMonth возвращает первый день месяца, которому принадлежит дата d
+func (d Date) StartOfMonth() Date {
+	d.Time = setDate(d.Time, d.Year(), d.Month(), 1, 0, 0, 0)
+	return d
+}
+
+// EndOfMonth возвращает последний день месяца, которому принадлежит дата d
+func (d Date) EndOfMonth() Date {
+	d.Time = setDate(d.Time, d.Year(), d.Month(), d.LastDayOfMonth(), 0, 0, 0)
+	return d
+}
+
+// StartOfQuarter возвращает первый день квартала, которому принадлежит дата d
+func (d Date) StartOfQuarter() Date {
+	d.Time = setDate(d.Time, d.Year(), quarterMonth(d.Month()), 1, 0, 0, 0)
+	return d
+}
+
+// EndOfQuarter возвращает последний день квартала, которому принадлежит дата d
+func (d Date) EndOfQuarter() Date {
+	month := quarterMonth(d.Month()) + 2
+	d.Time = setDate(d.Time, d.Year(), month, daysInMonth(d.Year(), month), 0, 0, 0)
+	return d
+}
+
+// StartOfYear возвращает первый день года, которому принадлежит дата d
+func (d Date) StartOfYear() Date {
+	d.Time = setDate(d.Time, d.Year(), time.January, 1, 0, 0, 0)
+	return d
+}
+
+// EndOfYear возвращает последний день года, которому принадлежит дата d
+func (d Date) EndOfYear() Date {
+	d.Time = setDate(d.Time, d.Year(), time.December, 31, 0, 0, 0)
+	return d
+}
+
+// AddMonths прибавляет к дате d months месяцев. В отличие от Add, если в
+// получившемся месяце нет дня d, возвращается последний день этого месяца:
+// 2017-01-31 + 1 месяц = 2017-02-28
+func (d Date) AddMonths(months int) Date {
+	d.Time = addMonths(d.Time, months)
+	return d
+}
+
+// LastDayOfMonth возвращает номер последнего дня месяца, которому принадлежит дата-время d
+func (d DateTime) LastDayOfMonth() int {
+	return daysInMonth(d.Year(), d.Month())
+}
+
+// StartOfDay возвращает начало дня (00:00:00), которому принадлежит дата-время d
+func (d DateTime) StartOfDay() DateTime {
+	d.Time = setDate(d.Time, d.Year(), d.Month(), d.Day(), 0, 0, 0)
+	return d
+}
+
+// EndOfDay возвращает конец дня (23:59:59), которому принадлежит дата-время d
+func (d DateTime) EndOfDay() DateTime {
+	d.Time = setDate(d.Time, d.Year(), d.Month(), d.Day(), 23, 59, 59)
+	return d
+}
+
+// StartOfWeek возвращает начало первого дня недели, которой принадлежит дата-время d.
+// Неделя начинается с понедельника
+func (d DateTime) StartOfWeek() DateTime {
+	return d.StartOfWeekFrom(time.Monday)
+}
+
+// EndOfWeek возвращает конец последнего дня недели, которой принадлежит дата-время d.
+// Неделя начинается с понедельника
+func (d DateTime) EndOfWeek() DateTime {
+	return d.EndOfWeekFrom(time.Monday)
+}
+
+// StartOfWeekFrom возвращает начало первого дня недели, которой принадлежит
+// дата-время d, если неделя начинается с дня first
+func (d DateTime) StartOfWeekFrom(first time.Weekday) DateTime {
+	d.Time = d.Time.AddDate(0, 0, -weekOffset(d.Weekday(), first))
+	return d.StartOfDay()
+}
+
+// EndOfWeekFrom возвращает конец последнего дня недели, которой принадлежит
+// дата-время d, если неделя начинается с дня first
+func (d DateTime) EndOfWeekFrom(first time.Weekday) DateTime {
+	d.Time = d.Time.AddDate(0, 0, 6-weekOffset(d.Weekday(), first))
+	return d.EndOfDay()
+}
+
+// StartOfMonth возвращает начало первого дня месяца, которому принадлежит дата-время d
+func (d DateTime) StartOfMonth() DateTime {
+	d.Time = setDate(d.Time, d.Year(), d.Month(), 1, 0, 0, 0)
+	return d
+}
+
+// EndOfMonth возвращает конец последнего дня месяца, которому принадлежит дата-время d
+func (d DateTime) EndOfMonth() DateTime {
+	d.Time = setDate(d.Time, d.Year(), d.Month(), d.LastDayOfMonth(), 23, 59, 59)
+	return d
+}
+
+// StartOfQuarter возвращает начало первого дня квартала, которому принадлежит дата-время d
+func (d DateTime) StartOfQuarter() DateTime {
+	d.Time = setDate(d.Time, d.Year(), quarterMonth(d.Month()), 1, 0, 0, 0)
+	return d
+}
+
+// EndOfQuarter возвращает конец последнего дня квартала, которому принадлежит дата-время d
+func (d DateTime) EndOfQuarter() DateTime {
+	month := quarterMonth(d.Month()) + 2
+	d.Time = setDate(d.Time, d.Year(), month, daysInMonth(d.Year(), month), 23, 59, 59)
+	return d
+}
+
+// StartOfYear возвращает начало первого дня года, которому принадлежит дата-время d
+func (d DateTime) StartOfYear() DateTime {
+	d.Time = setDate(d.Time, d.Year(), time.January, 1, 0, 0, 0)
+	return d
+}
+
+// EndOfYear возвращает конец последнего дня года, которому принадлежит дата-время d
+func (d DateTime) EndOfYear() DateTime {
+	d.Time = setDate(d.Time, d.Year(), time.December, 31, 23, 59, 59)
+	return d
+}
+
+// AddMonths прибавляет к дате-времени d months месяцев. В отличие от Add, если в
+// получившемся месяце нет дня d, возвращается последний день этого месяца
+// с тем же временем: 2017-01-31 10:00:00 + 1 месяц = 2017-02-28 10:00:00
+func (d DateTime) AddMonths(months int) DateTime {
+	d.Time = addMonths(d.Time, months)
+	return d
+}
@@ -0,0 +1,161 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateCalendarBoundaries(t *testing.T) {
+	d, err := StringToDate("2017-08-16") // среда
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		received Date
+		expected string
+	}{
+		{"StartOfWeek", d.StartOfWeek(), "2017-08-14"},
+		{"EndOfWeek", d.EndOfWeek(), "2017-08-20"},
+		{"StartOfMonth", d.StartOfMonth(), "2017-08-01"},
+		{"EndOfMonth", d.EndOfMonth(), "2017-08-31"},
+		{"StartOfQuarter", d.StartOfQuarter(), "2017-07-01"},
+		{"EndOfQuarter", d.EndOfQuarter(), "2017-09-30"},
+		{"StartOfYear", d.StartOfYear(), "2017-01-01"},
+		{"EndOfYear", d.EndOfYear(), "2017-12-31"},
+	}
+	for _, test := range tests {
+		if s := test.received.String(); s != test.expected {
+			t.Fatalf("%s: ожидалось %s, получено %s", test.name, test.expected, s)
+		}
+	}
+}
+
+func TestDateTimeCalendarBoundaries(t *testing.T) {
+	dt, err := StringToDateTime("2016-02-10 13:14:15")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		received DateTime
+		expected string
+	}{
+		{"StartOfDay", dt.StartOfDay(), "2016-02-10 00:00:00"},
+		{"EndOfDay", dt.EndOfDay(), "2016-02-10 23:59:59"},
+		{"StartOfWeek", dt.StartOfWeek(), "2016-02-08 00:00:00"},
+		{"EndOfWeek", dt.EndOfWeek(), "2016-02-14 23:59:59"},
+		{"StartOfMonth", dt.StartOfMonth(), "2016-02-01 00:00:00"},
+		{"EndOfMonth", dt.EndOfMonth(), "2016-02-29 23:59:59"},
+		{"StartOfQuarter", dt.StartOfQuarter(), "2016-01-01 00:00:00"},
+		{"EndOfQuarter", dt.EndOfQuarter(), "2016-03-31 23:59:59"},
+		{"StartOfYear", dt.StartOfYear(), "2016-01-01 00:00:00"},
+		{"EndOfYear", dt.EndOfYear(), "2016-12-31 23:59:59"},
+	}
+	for _, test := range tests {
+		if s := test.received.String(); s != test.expected {
+			t.Fatalf("%s: ожидалось %s, получено %s", test.name, test.expected, s)
+		}
+	}
+}
+
+func TestDateTimeCalendarBoundariesLocation(t *testing.T) {
+	// границы считаются в часовом поясе значения, а не в поясе по умолчанию
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	dt := NewDateTime()
+	dt.setTime(time.Date(2017, time.July, 14, 12, 0, 0, 0, loc))
+	tests := []struct {
+		name     string
+		received DateTime
+		expected time.Time
+	}{
+		{"StartOfDay", dt.StartOfDay(), time.Date(2017, time.July, 14, 0, 0, 0, 0, loc)},
+		{"EndOfDay", dt.EndOfDay(), time.Date(2017, time.July, 14, 23, 59, 59, 0, loc)},
+		{"StartOfWeek", dt.StartOfWeek(), time.Date(2017, time.July, 10, 0, 0, 0, 0, loc)},
+		{"EndOfWeek", dt.EndOfWeek(), time.Date(2017, time.July, 16, 23, 59, 59, 0, loc)},
+		{"StartOfMonth", dt.StartOfMonth(), time.Date(2017, time.July, 1, 0, 0, 0, 0, loc)},
+	}
+	for _, test := range tests {
+		if !test.received.Time.Equal(test.expected) || test.received.Location() != loc {
+			t.Fatalf("%s: ожидалось %s, получено %s", test.name, test.expected, test.received.Time)
+		}
+	}
+}
+
+func TestStartOfWeekFrom(t *testing.T) {
+	d, err := StringToDate("2017-08-20") // воскресенье
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := d.StartOfWeekFrom(time.Sunday).String(); s != "2017-08-20" {
+		t.Fatalf("Ожидалось 2017-08-20, получено %s", s)
+	}
+	if s := d.EndOfWeekFrom(time.Sunday).String(); s != "2017-08-26" {
+		t.Fatalf("Ожидалось 2017-08-26, получено %s", s)
+	}
+	if s := d.StartOfWeek().String(); s != "2017-08-14" {
+		t.Fatalf("Ожидалось 2017-08-14, получено %s", s)
+	}
+	if s := d.EndOfWeek().String(); s != "2017-08-20" {
+		t.Fatalf("Ожидалось 2017-08-20, получено %s", s)
+	}
+
+	dt := d.ConvertToDateTimeHMS(15, 0, 0)
+	if s := dt.StartOfWeekFrom(time.Saturday).String(); s != "2017-08-19 00:00:00" {
+		t.Fatalf("Ожидалось 2017-08-19 00:00:00, получено %s", s)
+	}
+	if s := dt.EndOfWeekFrom(time.Saturday).String(); s != "2017-08-25 23:59:59" {
+		t.Fatalf("Ожидалось 2017-08-25 23:59:59, получено %s", s)
+	}
+}
+
+func TestLastDayOfMonth(t *testing.T) {
+	tests := map[string]int{
+		"2016-02-01": 29,
+		"2017-02-15": 28,
+		"2017-04-30": 30,
+		"2017-12-31": 31,
+	}
+	for s, expected := range tests {
+		d, err := StringToDate(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if received := d.LastDayOfMonth(); received != expected {
+			t.Fatalf("%s: ожидалось %d, получено %d", s, expected, received)
+		}
+	}
+}
+
+func TestAddMonths(t *testing.T) {
+	tests := []struct {
+		date     string
+		months   int
+		expected string
+	}{
+		{"2017-01-31", 1, "2017-02-28"},
+		{"2016-01-31", 1, "2016-02-29"},
+		{"2017-03-31", -1, "2017-02-28"},
+		{"2017-05-31", 1, "2017-06-30"},
+		{"2017-11-15", 3, "2018-02-15"},
+		{"2017-01-15", -13, "2015-12-15"},
+		{"2016-02-29", 12, "2017-02-28"},
+	}
+	for _, test := range tests {
+		d, err := StringToDate(test.date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := d.AddMonths(test.months).String(); s != test.expected {
+			t.Fatalf("%s + %d: ожидалось %s, получено %s", test.date, test.months, test.expected, s)
+		}
+	}
+
+	dt, err := StringToDateTime("2017-01-31 10:20:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := dt.AddMonths(1).String(); s != "2017-02-28 10:20:30" {
+		t.Fatalf("Ожидалось 2017-02-28 10:20:30, получено %s", s)
+	}
+}
@@ -0,0 +1,220 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mihteh/types/internal/cbor"
+)
+
+// Теги и простые значения CBOR, используемые для времени
+const (
+	cborTagDateTimeString = 0
+	cborTagEpochDateTime  = 1
+	cborTagFullDate       = 1004
+	cborNull              = 22
+	cborUndefined         = 23
+	cborFloat32           = 26
+	cborFloat64           = 27
+)
+
+// cborAppendText добавляет к b текстовую строку CBOR
+func cborAppendText(b []byte, s string) []byte {
+	return append(cbor.AppendHead(b, cbor.MajorText, uint64(len(s))), s...)
+}
+
+// cborReadHead читает заголовок элемента CBOR с помощью cbor.ReadHead
+// и переводит ошибки разбора на русский язык
+func cborReadHead(data []byte) (major, info byte, n uint64, rest []byte, err error) {
+	major, info, n, rest, err = cbor.ReadHead(data)
+	var e *cbor.Error
+	if !errors.As(err, &e) {
+		return major, info, n, rest, err
+	}
+	if e.Unsupported {
+		return 0, 0, 0, nil, fmt.Errorf("Неверный формат CBOR: неподдерживаемый заголовок 0x%02x", e.InitialByte)
+	}
+	return 0, 0, 0, nil, errors.New("Неверный формат CBOR: недостаточно данных")
+}
+
+// cborReadText читает текстовую строку CBOR
+func cborReadText(data []byte) (string, []byte, error) {
+	major, _, n, rest, err := cborReadHead(data)
+	if err != nil {
+		return "", nil, err
+	}
+	if major != cbor.MajorText {
+		return "", nil, errors.New("Неверный формат CBOR: ожидалась текстовая строка")
+	}
+	if n > uint64(len(rest)) {
+		return "", nil, errors.New("Неверный формат CBOR: недостаточно данных")
+	}
+	return string(rest[:n]), rest[n:], nil
+}
+
+// cborReadEpoch читает число секунд от начала эпохи Unix (тег 1):
+// целое или число с плавающей точкой
+func cborReadEpoch(data []byte) (time.Time, []byte, error) {
+	major, info, n, rest, err := cborReadHead(data)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	var f float64
+	switch {
+	case major == cbor.MajorUint && n <= math.MaxInt64:
+		return time.Unix(int64(n), 0), rest, nil
+	case major == cbor.MajorNegInt && n <= math.MaxInt64:
+		return time.Unix(-1-int64(n), 0), rest, nil
+	case major == cbor.MajorSimple && info == cborFloat32:
+		f = float64(math.Float32frombits(uint32(n)))
+	case major == cbor.MajorSimple && info == cborFloat64:
+		f = math.Float64frombits(n)
+	default:
+		return time.Time{}, nil, errors.New("Неверный формат CBOR: ожидалось число секунд")
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) || math.Abs(f) >= 1<<63 {
+		return time.Time{}, nil, fmt.Errorf("Неверный формат CBOR: недопустимое число секунд %v", f)
+	}
+	sec := math.Floor(f)
+	return time.Unix(int64(sec), int64(math.Round((f-sec)*1e9))), rest, nil
+}
+
+// readCBORTime разбирает время из элементов с тегами 0, 1 и 1004
+// в часовом поясе по умолчанию. Для null и undefined возвращается null = true
+func readCBORTime(data []byte) (t time.Time, null bool, err error) {
+	major, info, tag, rest, err := cborReadHead(data)
+	if err != nil {
+		return t, false, err
+	}
+	if major == cbor.MajorSimple && (info == cborNull || info == cborUndefined) {
+		null = true
+	} else if major != cbor.MajorTag {
+		return t, false, errors.New("Неверный формат CBOR: ожидался тег даты-времени")
+	} else {
+		var s string
+		switch tag {
+		case cborTagDateTimeString:
+			if s, rest, err = cborReadText(rest); err == nil {
+				t, err = time.Parse(time.RFC3339Nano, s)
+			}
+		case cborTagEpochDateTime:
+			t, rest, err = cborReadEpoch(rest)
+		case cborTagFullDate:
+			if s, rest, err = cborReadText(rest); err == nil {
+				t, err = time.ParseInLocation(DateLayout, s, defaultLocation)
+			}
+		default:
+			return t, false, fmt.Errorf("Неверный формат CBOR: неподдерживаемый тег %d", tag)
+		}
+		if err != nil {
+			return t, false, err
+		}
+	}
+	if len(rest) != 0 {
+		return t, false, errors.New("Неверный формат CBOR: лишние данные после значения")
+	}
+	return t.In(defaultLocation), null, nil
+}
+
+// MarshalCBOR преобразует DateTime в CBOR: время без долей секунды
+// кодируется тегом 1 с целым числом секунд, иначе тегом 0 со строкой RFC 3339
+// Совместим с интерфейсом cbor.Marshaler
+func (d DateTime) MarshalCBOR() ([]byte, error) {
+	if d.Nanosecond() != 0 {
+		b := cbor.AppendHead(nil, cbor.MajorTag, cborTagDateTimeString)
+		return cborAppendText(b, d.Format(time.RFC3339Nano)), nil
+	}
+	b := cbor.AppendHead(nil, cbor.MajorTag, cborTagEpochDateTime)
+	sec := d.Unix()
+	if sec < 0 {
+		return cbor.AppendHead(b, cbor.MajorNegInt, uint64(-1-sec)), nil
+	}
+	return cbor.AppendHead(b, cbor.MajorUint, uint64(sec)), nil
+}
+
+// UnmarshalCBOR разбирает DateTime из CBOR с тегом 0, 1 или 1004
+// Совместим с интерфейсом cbor.Unmarshaler
+func (d *DateTime) UnmarshalCBOR(data []byte) error {
+	t, null, err := readCBORTime(data)
+	if err != nil {
+		return err
+	}
+	if null {
+		return errors.New("Ошибка UnmarshalCBOR: значение null не может быть записано в DateTime")
+	}
+	d.fixLayout()
+	d.setTime(t)
+	return nil
+}
+
+// MarshalCBOR преобразует Date в CBOR: строка RFC 3339 full-date с тегом 1004
+// Совместим с интерфейсом cbor.Marshaler
+func (d Date) MarshalCBOR() ([]byte, error) {
+	b := cbor.AppendHead(nil, cbor.MajorTag, cborTagFullDate)
+	return cborAppendText(b, d.Format(DateLayout)), nil
+}
+
+// UnmarshalCBOR разбирает Date из CBOR с тегом 0, 1 или 1004, время отбрасывается
+// Совместим с интерфейсом cbor.Unmarshaler
+func (d *Date) UnmarshalCBOR(data []byte) error {
+	t, null, err := readCBORTime(data)
+	if err != nil {
+		return err
+	}
+	if null {
+		return errors.New("Ошибка UnmarshalCBOR: значение null не может быть записано в Date")
+	}
+	d.fixLayout()
+	d.setTime(ToDate(t).Time)
+	return nil
+}
+
+// MarshalCBOR преобразует NullDateTime в CBOR,
+// значение NULL преобразуется в null
+func (d NullDateTime) MarshalCBOR() ([]byte, error) {
+	if !d.Valid {
+		return cbor.AppendHead(nil, cbor.MajorSimple, cborNull), nil
+	}
+	return d.DateTime.MarshalCBOR()
+}
+
+// UnmarshalCBOR разбирает NullDateTime из CBOR,
+// null и undefined преобразуются в значение NULL
+func (d *NullDateTime) UnmarshalCBOR(data []byte) error {
+	t, null, err := readCBORTime(data)
+	if err != nil {
+		return err
+	}
+	d.Valid = !null
+	if d.Valid {
+		d.fixLayout()
+		d.setTime(t)
+	}
+	return nil
+}
+
+// MarshalCBOR преобразует NullDate в CBOR,
+// значение NULL преобразуется в null
+func (d NullDate) MarshalCBOR() ([]byte, error) {
+	if !d.Valid {
+		return cbor.AppendHead(nil, cbor.MajorSimple, cborNull), nil
+	}
+	return d.Date.MarshalCBOR()
+}
+
+// UnmarshalCBOR разбирает NullDate из CBOR,
+// null и undefined преобразуются в значение NULL
+func (d *NullDate) UnmarshalCBOR(data []byte) error {
+	t, null, err := readCBORTime(data)
+	if err != nil {
+		return err
+	}
+	d.Valid = !null
+	if d.Valid {
+		d.fixLayout()
+		d.setTime(ToDate(t).Time)
+	}
+	return nil
+}
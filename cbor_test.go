@@ -0,0 +1,115 @@
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+)
+
+func TestDateTimeCBOR(t *testing.T) {
+	// примеры из RFC 8949
+	tests := []struct {
+		t   time.Time
+		hex string
+	}{
+		{time.Unix(1363896240, 0), "c11a514b67b0"},
+		{time.Date(2013, 3, 21, 20, 4, 0, 500000000, time.UTC), "c076323031332d30332d32315432303a30343a30302e355a"},
+		{time.Unix(-1, 0), "c120"},
+	}
+	for _, test := range tests {
+		dt := DateTime{Time: test.t}
+		b, err := dt.MarshalCBOR()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := hex.EncodeToString(b); s != test.hex {
+			t.Fatalf("%v: ожидалось %s, получено %s", test.t, test.hex, s)
+		}
+		var dt2 DateTime
+		if err := dt2.UnmarshalCBOR(b); err != nil {
+			t.Fatal(err)
+		}
+		if !dt2.Equal(dt) || dt2.Location() != defaultLocation || dt2.Layout != DateTimeLayout {
+			t.Fatalf("Ожидалось %v, получено %#v", dt.Time, dt2)
+		}
+	}
+
+	decoded := map[string]time.Time{
+		"c074323031332d30332d32315432303a30343a30305a": time.Unix(1363896240, 0),
+		"c1fb41d452d9ec200000":                         time.Unix(1363896240, 500000000),
+		"c1fa4f800000":                                 time.Unix(1<<32, 0),
+		"c13a00000001":                                 time.Unix(-2, 0),
+		"c1fbbff8000000000000":                         time.Unix(-2, 500000000),
+	}
+	for s, expected := range decoded {
+		data, _ := hex.DecodeString(s)
+		var dt DateTime
+		if err := dt.UnmarshalCBOR(data); err != nil {
+			t.Fatalf("%s: %s", s, err)
+		}
+		if !dt.Equal(DateTime{Time: expected}) {
+			t.Fatalf("%s: ожидалось %v, получено %v", s, expected, dt.Time)
+		}
+	}
+
+	for _, s := range []string{"", "f6", "1a514b67b0", "c2", "c11a514b", "c174", "c0780a", "c1fb7ff8000000000000", "c1f97c00", "c11a514b67b000", "c06161"} {
+		data, _ := hex.DecodeString(s)
+		var dt DateTime
+		if err := dt.UnmarshalCBOR(data); err == nil {
+			t.Fatalf("%s: ожидалась ошибка", s)
+		}
+	}
+}
+
+func TestDateNullCBOR(t *testing.T) {
+	d := ToDate(time.Date(1940, 10, 9, 0, 0, 0, 0, time.UTC))
+	b, err := d.MarshalCBOR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// пример из RFC 8943
+	if s := hex.EncodeToString(b); s != "d903ec6a313934302d31302d3039" {
+		t.Fatalf("Ожидалось d903ec6a313934302d31302d3039, получено %s", s)
+	}
+	d2 := Date{Layout: GraphsDateLayout}
+	if err := d2.UnmarshalCBOR(b); err != nil {
+		t.Fatal(err)
+	}
+	if !d2.Equal(d) || d2.String() != "09.10.1940" {
+		t.Fatalf("Ожидалось 09.10.1940, получено %v", d2)
+	}
+
+	// дата-время с тегом 1 преобразуется в дату в часовом поясе по умолчанию
+	b, _ = DateTime{Time: d.Time.Add(15 * time.Hour)}.MarshalCBOR()
+	if err := d2.UnmarshalCBOR(b); err != nil || !d2.Equal(d) {
+		t.Fatalf("Ожидалось %v, получено %v, %v", d, d2, err)
+	}
+
+	b, err = MakeNullDate().MarshalCBOR()
+	if err != nil || hex.EncodeToString(b) != "f6" {
+		t.Fatalf("Ожидалось f6, получено %x, %v", b, err)
+	}
+	nd := d.Nullable()
+	if err := nd.UnmarshalCBOR(b); err != nil || nd.Valid {
+		t.Fatalf("Ожидалось NULL, получено %v, %v", nd, err)
+	}
+	if err := d2.UnmarshalCBOR(b); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+	if err := nd.UnmarshalCBOR([]byte{0xd9, 0x03, 0xec, 0x6a}); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+
+	ndt := DateTime{Time: time.Unix(1363896240, 0)}.Nullable()
+	b, err = ndt.MarshalCBOR()
+	if err != nil || hex.EncodeToString(b) != "c11a514b67b0" {
+		t.Fatalf("Ожидалось c11a514b67b0, получено %x, %v", b, err)
+	}
+	ndt2 := MakeNullDateTime()
+	if err := ndt2.UnmarshalCBOR(b); err != nil || !ndt2.Valid || !ndt2.Equal(ndt.DateTime) {
+		t.Fatalf("Ожидалось %v, получено %v, %v", ndt, ndt2, err)
+	}
+	if err := ndt2.UnmarshalCBOR([]byte{0xf7}); err != nil || ndt2.Valid {
+		t.Fatalf("Ожидалось NULL, получено %v, %v", ndt2, err)
+	}
+}
@@ -618,7 +618,7 @@ func (d *NullDateTime) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
-	pobj := &DateTime{}
+	pobj := &DateTime{Layout: d.Layout}
 	err = pobj.UnmarshalJSON(data)
 	d.DateTime = *pobj
 	d.Valid = err == nil
@@ -637,7 +637,7 @@ func (d *NullDate) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
-	pobj := &Date{}
+	pobj := &Date{Layout: d.Layout}
 	err = pobj.UnmarshalJSON(data)
 	d.Date = *pobj
 	d.Valid = err == nil
@@ -696,7 +696,7 @@ func (d *NullDateTime) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement
 	var iContent interface{}
 	iContent = content
 
-	pobj := &DateTime{}
+	pobj := &DateTime{Layout: d.Layout}
 	pobj.fixLayout()
 
 	f, err := strconv.ParseFloat(content, 64)
@@ -735,7 +735,7 @@ func (d *NullDate) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) er
 	var iContent interface{}
 	iContent = content
 
-	pobj := &Date{}
+	pobj := &Date{Layout: d.Layout}
 	pobj.fixLayout()
 
 	f, err := strconv.ParseFloat(content, 64)
@@ -801,3 +801,186 @@ func OldNeverDate() Date {
 	t, _ := time.ParseInLocation(DateLayout, "1990-01-01", defaultLocation)
 	return ToDate(t)
 }
+
+// parseText устанавливает время в объекте, реализующем интерфейс timeModifier,
+// на основе строки s. Если s не соответствует шаблону, но является числом,
+// оно считается timestamp UTC в миллисекундах
+func parseText(d timeModifier, s string) error {
+	err := parse(d, s)
+	if err == nil {
+		return nil
+	}
+	if f, ferr := strconv.ParseFloat(s, 64); ferr == nil {
+		return parse(d, f)
+	}
+	return err
+}
+
+// marshalText преобразует время t в текст согласно шаблону layout
+// или в timestamp UTC, если установлен флаг toTimeStamp
+func marshalText(t time.Time, layout string, toTimeStamp bool) []byte {
+	if toTimeStamp {
+		return []byte(fmt.Sprintf("%d", t.Unix()*timeStampMultiplier))
+	}
+	return []byte(t.Format(layout))
+}
+
+// MarshalText реализует интерфейс encoding.TextMarshaler для объекта DateTime
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (d DateTime) MarshalText() ([]byte, error) {
+	d.fixLayout()
+	return marshalText(d.Time, d.Layout, d.marshalToUTCTimeStamp), nil
+}
+
+// AppendText реализует интерфейс encoding.TextAppender для объекта DateTime
+// и заменяет одноимённый метод встроенного time.Time, чтобы учитывать Layout
+func (d DateTime) AppendText(b []byte) ([]byte, error) {
+	text, err := d.MarshalText()
+	return append(b, text...), err
+}
+
+// UnmarshalText реализует интерфейс encoding.TextUnmarshaler для объекта DateTime
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (d *DateTime) UnmarshalText(text []byte) error {
+	d.fixLayout()
+	return parseText(d, string(text))
+}
+
+// MarshalXMLAttr реализует интерфейс xml.MarshalerAttr для объекта DateTime
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (d DateTime) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	text, err := d.MarshalText()
+	return xml.Attr{Name: name, Value: string(text)}, err
+}
+
+// UnmarshalXMLAttr реализует интерфейс xml.UnmarshalerAttr для объекта DateTime
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (d *DateTime) UnmarshalXMLAttr(attr xml.Attr) error {
+	return d.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalText реализует интерфейс encoding.TextMarshaler для объекта Date
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (d Date) MarshalText() ([]byte, error) {
+	d.fixLayout()
+	return marshalText(d.Time, d.Layout, d.marshalToUTCTimeStamp), nil
+}
+
+// AppendText реализует интерфейс encoding.TextAppender для объекта Date
+// и заменяет одноимённый метод встроенного time.Time, чтобы учитывать Layout
+func (d Date) AppendText(b []byte) ([]byte, error) {
+	text, err := d.MarshalText()
+	return append(b, text...), err
+}
+
+// UnmarshalText реализует интерфейс encoding.TextUnmarshaler для объекта Date
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (d *Date) UnmarshalText(text []byte) error {
+	d.fixLayout()
+	return parseText(d, string(text))
+}
+
+// MarshalXMLAttr реализует интерфейс xml.MarshalerAttr для объекта Date
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (d Date) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	text, err := d.MarshalText()
+	return xml.Attr{Name: name, Value: string(text)}, err
+}
+
+// UnmarshalXMLAttr реализует интерфейс xml.UnmarshalerAttr для объекта Date
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (d *Date) UnmarshalXMLAttr(attr xml.Attr) error {
+	return d.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalText реализует интерфейс encoding.TextMarshaler для объекта NullDateTime
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout,
+// значение NULL преобразуется в пустую строку
+func (d NullDateTime) MarshalText() ([]byte, error) {
+	if !d.Valid {
+		return []byte{}, nil
+	}
+	return d.DateTime.MarshalText()
+}
+
+// AppendText реализует интерфейс encoding.TextAppender для объекта NullDateTime
+// и заменяет одноимённый метод встроенного time.Time, чтобы учитывать Layout
+func (d NullDateTime) AppendText(b []byte) ([]byte, error) {
+	text, err := d.MarshalText()
+	return append(b, text...), err
+}
+
+// UnmarshalText реализует интерфейс encoding.TextUnmarshaler для объекта NullDateTime
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout,
+// пустая строка преобразуется в значение NULL
+func (d *NullDateTime) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		d.Valid = false
+		return nil
+	}
+	err := d.DateTime.UnmarshalText(text)
+	d.Valid = err == nil
+	return err
+}
+
+// MarshalXMLAttr реализует интерфейс xml.MarshalerAttr для объекта NullDateTime
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout,
+// для значения NULL атрибут не выводится
+func (d NullDateTime) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !d.Valid {
+		return xml.Attr{}, nil
+	}
+	return d.DateTime.MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr реализует интерфейс xml.UnmarshalerAttr для объекта NullDateTime
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (d *NullDateTime) UnmarshalXMLAttr(attr xml.Attr) error {
+	return d.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalText реализует интерфейс encoding.TextMarshaler для объекта NullDate
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout,
+// значение NULL преобразуется в пустую строку
+func (d NullDate) MarshalText() ([]byte, error) {
+	if !d.Valid {
+		return []byte{}, nil
+	}
+	return d.Date.MarshalText()
+}
+
+// AppendText реализует интерфейс encoding.TextAppender для объекта NullDate
+// и заменяет одноимённый метод встроенного time.Time, чтобы учитывать Layout
+func (d NullDate) AppendText(b []byte) ([]byte, error) {
+	text, err := d.MarshalText()
+	return append(b, text...), err
+}
+
+// UnmarshalText реализует интерфейс encoding.TextUnmarshaler для объекта NullDate
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout,
+// пустая строка преобразуется в значение NULL
+func (d *NullDate) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		d.Valid = false
+		return nil
+	}
+	err := d.Date.UnmarshalText(text)
+	d.Valid = err == nil
+	return err
+}
+
+// MarshalXMLAttr реализует интерфейс xml.MarshalerAttr для объекта NullDate
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout,
+// для значения NULL атрибут не выводится
+func (d NullDate) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !d.Valid {
+		return xml.Attr{}, nil
+	}
+	return d.Date.MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr реализует интерфейс xml.UnmarshalerAttr для объекта NullDate
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (d *NullDate) UnmarshalXMLAttr(attr xml.Attr) error {
+	return d.UnmarshalText([]byte(attr.Value))
+}
@@ -897,3 +897,115 @@ func TestNullDateUrlEncodeIfNotValid(t *testing.T) {
 		t.Fatalf("Ожидалось: %v, получено: %v", expectedValues, values)
 	}
 }
+
+func TestDateTimeDateAsJSONMapKey(t *testing.T) {
+	d, err := StringToDate("2017-07-14")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dt, err := StringToDateTime("2017-07-14 15:04:05")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(map[Date]int{d: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"2017-07-14":1}`; string(b) != expected {
+		t.Fatalf("Ожидалось %s, получено %s", expected, b)
+	}
+	var dates map[Date]int
+	if err := json.Unmarshal(b, &dates); err != nil {
+		t.Fatal(err)
+	}
+	for key := range dates {
+		if key.String() != "2017-07-14" {
+			t.Fatalf("Ожидалось 2017-07-14, получено %s", key)
+		}
+	}
+
+	b, err = json.Marshal(map[DateTime]int{dt: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"2017-07-14 15:04:05":1}`; string(b) != expected {
+		t.Fatalf("Ожидалось %s, получено %s", expected, b)
+	}
+}
+
+func TestDateTimeDateText(t *testing.T) {
+	dt, err := StringToDateTime("2017-07-14 15:04:05")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dt.SetMarshalToUTCTimeStamp(true)
+	text, err := dt.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := fmt.Sprintf("%d", dt.Unix()*timeStampMultiplier); string(text) != expected {
+		t.Fatalf("Ожидалось %s, получено %s", expected, text)
+	}
+	var fromText DateTime
+	if err := fromText.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if !fromText.Equal(dt) {
+		t.Fatalf("Ожидалось %v, получено %v", dt, fromText)
+	}
+
+	d := Date{Layout: GraphsDateLayout}
+	if err := d.UnmarshalText([]byte("14.07.2017")); err != nil {
+		t.Fatal(err)
+	}
+	if text, err := d.MarshalText(); err != nil || string(text) != "14.07.2017" {
+		t.Fatalf("Ожидалось 14.07.2017, получено %s, %v", text, err)
+	}
+	if err := d.UnmarshalText([]byte("wrong")); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+
+	var nd NullDate
+	if err := nd.UnmarshalText([]byte("2017-07-14")); err != nil || !nd.Valid {
+		t.Fatalf("Ожидалась валидная дата, получено %v, %v", nd, err)
+	}
+	if err := nd.UnmarshalText([]byte{}); err != nil || nd.Valid {
+		t.Fatalf("Ожидалось NULL, получено %v, %v", nd, err)
+	}
+	if text, err := MakeNullDateTime().MarshalText(); err != nil || len(text) != 0 {
+		t.Fatalf("Ожидалась пустая строка, получено %s, %v", text, err)
+	}
+}
+
+func TestDateTimeDateXMLAttr(t *testing.T) {
+	type event struct {
+		XMLName xml.Name     `xml:"event"`
+		At      DateTime     `xml:"at,attr"`
+		On      Date         `xml:"on,attr"`
+		Until   NullDateTime `xml:"until,attr"`
+		Since   NullDate     `xml:"since,attr"`
+	}
+	dt, err := StringToDateTime("2017-07-14 15:04:05")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := event{At: dt, On: dt.ConvertToDate(), Until: MakeNullDateTime(), Since: dt.ConvertToDate().Nullable()}
+
+	b, err := xml.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `<event at="2017-07-14 15:04:05" on="2017-07-14" since="2017-07-14"></event>`
+	if string(b) != expected {
+		t.Fatalf("Ожидалось %s, получено %s", expected, b)
+	}
+
+	var fromXML event
+	if err := xml.Unmarshal(b, &fromXML); err != nil {
+		t.Fatal(err)
+	}
+	if !fromXML.At.Equal(e.At) || !fromXML.On.Equal(e.On) || fromXML.Until.Valid || !fromXML.Since.Valid {
+		t.Fatalf("Ожидалось %v, получено %v", e, fromXML)
+	}
+}
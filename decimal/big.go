@@ -0,0 +1,101 @@
+package decimal
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// NewFromBigInt returns value * 10 ^ exp. The value is copied, a nil value
+// is zero.
+func NewFromBigInt(value *big.Int, exp int32) Decimal {
+	if value == nil {
+		return New(0, exp)
+	}
+	return Decimal{value: new(big.Int).Set(value), exp: exp}
+}
+
+// NewFromUint64 returns value * 10 ^ exp. Unlike New, it accepts values
+// greater than math.MaxInt64.
+func NewFromUint64(value uint64, exp int32) Decimal {
+	return Decimal{value: new(big.Int).SetUint64(value), exp: exp}
+}
+
+// NewFromRat returns r rounded to places decimal places using mode and
+// reports whether the result equals r. A nil r is zero.
+//
+// Example:
+//
+//	NewFromRat(big.NewRat(2, 3), 4, RoundHalfUp) // output: 0.6667, false
+func NewFromRat(r *big.Rat, places int32, mode RoundingMode) (Decimal, bool) {
+	if r == nil {
+		return New(0, -places), true
+	}
+	d := divRound(NewFromBigInt(r.Num(), 0), NewFromBigInt(r.Denom(), 0), 0, places, mode)
+	return d, d.Rat().Cmp(r) == 0
+}
+
+// NewFromBigFloat returns the exact value of f. Every finite big.Float is a
+// decimal with as many fractional digits as it has binary ones. It returns
+// ErrNotFinite for infinities and ErrExponentOverflow if the exponent of the
+// result doesn't fit into an int32.
+func NewFromBigFloat(f *big.Float) (Decimal, error) {
+	if f.IsInf() {
+		return Decimal{}, fmt.Errorf("can't convert %v to decimal: %w", f, ErrNotFinite)
+	}
+	if f.IsInt() {
+		i, _ := f.Int(nil)
+		return Decimal{value: i, exp: 0}, nil
+	}
+	// the denominator is 2^k and num/2^k = num*5^k/10^k
+	r, _ := f.Rat(nil)
+	k := r.Denom().BitLen() - 1
+	if k > math.MaxInt32 {
+		return Decimal{}, fmt.Errorf("can't convert %v to decimal: %w", f, ErrExponentOverflow)
+	}
+	value := new(big.Int).Exp(fiveInt, big.NewInt(int64(k)), nil)
+	return Decimal{value: value.Mul(value, r.Num()), exp: int32(-k)}, nil
+}
+
+// BigInt returns the integer part of d, truncated towards zero as by IntPart.
+func (d Decimal) BigInt() *big.Int {
+	return d.rescale(0).value
+}
+
+// BigFloat returns the nearest big.Float value for d and a bool indicating
+// whether f represents d exactly. The precision of f is the bit length of
+// the larger of the numerator and the denominator of d.Rat, but at least 64.
+func (d Decimal) BigFloat() (f *big.Float, exact bool) {
+	f = new(big.Float).SetRat(d.Rat())
+	return f, f.Acc() == big.Exact
+}
+
+// Int64 returns the integer part of d, truncated towards zero, and reports
+// whether it fits into an int64. If it doesn't, the result is
+// math.MinInt64 or math.MaxInt64.
+func (d Decimal) Int64() (int64, bool) {
+	i := d.BigInt()
+	switch {
+	case i.IsInt64():
+		return i.Int64(), true
+	case i.Sign() < 0:
+		return math.MinInt64, false
+	default:
+		return math.MaxInt64, false
+	}
+}
+
+// Uint64 returns the integer part of d, truncated towards zero, and reports
+// whether it fits into a uint64. If it doesn't, the result is 0 for
+// negative values and math.MaxUint64 otherwise.
+func (d Decimal) Uint64() (uint64, bool) {
+	i := d.BigInt()
+	switch {
+	case i.IsUint64():
+		return i.Uint64(), true
+	case i.Sign() < 0:
+		return 0, false
+	default:
+		return math.MaxUint64, false
+	}
+}
@@ -0,0 +1,122 @@
+package decimal
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestNewFromBigInt(t *testing.T) {
+	i, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	d := NewFromBigInt(i, -2)
+	if expected := "1234567890123456789012345678.9"; d.String() != expected {
+		t.Errorf("expected %s, got %s", expected, d)
+	}
+	i.SetInt64(0)
+	if d.Equals(Zero) {
+		t.Error("expected the value to be copied")
+	}
+	if d := NewFromBigInt(nil, 0); !d.Equals(Zero) {
+		t.Errorf("expected 0, got %s", d)
+	}
+	if d := NewFromUint64(math.MaxUint64, -1); d.String() != "1844674407370955161.5" {
+		t.Errorf("expected 1844674407370955161.5, got %s", d)
+	}
+}
+
+func TestNewFromRat(t *testing.T) {
+	for _, testCase := range []struct {
+		r        *big.Rat
+		places   int32
+		mode     RoundingMode
+		expected string
+		exact    bool
+	}{
+		{big.NewRat(2, 3), 4, RoundHalfUp, "0.6667", false},
+		{big.NewRat(2, 3), 4, RoundDown, "0.6666", false},
+		{big.NewRat(-2, 3), 4, RoundFloor, "-0.6667", false},
+		{big.NewRat(1, 8), 2, RoundHalfEven, "0.12", false},
+		{big.NewRat(1, 8), 3, RoundHalfEven, "0.125", true},
+		{big.NewRat(1, 8), 2, RoundDown, "0.12", false},
+		{big.NewRat(12345, 1), -2, RoundHalfUp, "12300", false},
+		{big.NewRat(7, 1), 2, RoundHalfUp, "7", true},
+		{nil, 2, RoundHalfUp, "0", true},
+	} {
+		d, exact := NewFromRat(testCase.r, testCase.places, testCase.mode)
+		if !d.Equals(N(testCase.expected)) || d.exp != -testCase.places || exact != testCase.exact {
+			t.Errorf("%v: expected %s, %v, got %s (exp %d), %v", testCase.r, testCase.expected, testCase.exact, d, d.exp, exact)
+		}
+	}
+}
+
+func TestNewFromBigFloat(t *testing.T) {
+	for _, testCase := range []struct {
+		f        *big.Float
+		expected string
+	}{
+		{big.NewFloat(0), "0"},
+		{big.NewFloat(-2.5), "-2.5"},
+		{big.NewFloat(0.1), "0.1000000000000000055511151231257827021181583404541015625"},
+		{big.NewFloat(1e20), "100000000000000000000"},
+		{new(big.Float).SetMantExp(big.NewFloat(1), -10), "0.0009765625"},
+		{new(big.Float).SetMantExp(big.NewFloat(3), 100), "3802951800684688204490109616128"},
+	} {
+		d, err := NewFromBigFloat(testCase.f)
+		if err != nil {
+			t.Errorf("%v: %v", testCase.f, err)
+		} else if d.String() != testCase.expected {
+			t.Errorf("%v: expected %s, got %s", testCase.f, testCase.expected, d)
+		}
+	}
+	if _, err := NewFromBigFloat(new(big.Float).SetInf(true)); !errors.Is(err, ErrNotFinite) {
+		t.Errorf("expected ErrNotFinite, got %v", err)
+	}
+}
+
+func TestBigFloat(t *testing.T) {
+	if f, exact := N("2.5").BigFloat(); !exact || f.String() != "2.5" {
+		t.Errorf("expected exact 2.5, got %s, %v", f, exact)
+	}
+	if f, exact := N("0.1").BigFloat(); exact || f.Text('g', 10) != "0.1" {
+		t.Errorf("expected inexact 0.1, got %s, %v", f, exact)
+	}
+	d := N("-123.456789")
+	f, _ := d.BigFloat()
+	if back, _ := NewFromBigFloat(f); !back.Round(6).Equals(d) {
+		t.Errorf("expected %s, got %s", d, back)
+	}
+}
+
+func TestIntConversions(t *testing.T) {
+	for _, testCase := range []struct {
+		d       string
+		i64     int64
+		ok64    bool
+		u64     uint64
+		okU64   bool
+		integer string
+	}{
+		{"12.9", 12, true, 12, true, "12"},
+		{"-12.9", -12, true, 0, false, "-12"},
+		{"-0.5", 0, true, 0, true, "0"},
+		{"9223372036854775807", math.MaxInt64, true, math.MaxInt64, true, "9223372036854775807"},
+		{"9223372036854775808", math.MaxInt64, false, 1 << 63, true, "9223372036854775808"},
+		{"-9223372036854775808.9", math.MinInt64, true, 0, false, "-9223372036854775808"},
+		{"-9223372036854775809", math.MinInt64, false, 0, false, "-9223372036854775809"},
+		{"18446744073709551615.5", math.MaxInt64, false, math.MaxUint64, true, "18446744073709551615"},
+		{"18446744073709551616", math.MaxInt64, false, math.MaxUint64, false, "18446744073709551616"},
+		{"1E+30", math.MaxInt64, false, math.MaxUint64, false, "1000000000000000000000000000000"},
+	} {
+		d := N(testCase.d)
+		if i, ok := d.Int64(); i != testCase.i64 || ok != testCase.ok64 {
+			t.Errorf("%s: expected %d, %v, got %d, %v", testCase.d, testCase.i64, testCase.ok64, i, ok)
+		}
+		if u, ok := d.Uint64(); u != testCase.u64 || ok != testCase.okU64 {
+			t.Errorf("%s: expected %d, %v, got %d, %v", testCase.d, testCase.u64, testCase.okU64, u, ok)
+		}
+		if i := d.BigInt(); i.String() != testCase.integer {
+			t.Errorf("%s: expected %s, got %s", testCase.d, testCase.integer, i)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/mihteh/types/internal/cbor"
+)
+
+// CBOR tags used for decimals
+const (
+	cborTagPositiveBignum  = 2
+	cborTagNegativeBignum  = 3
+	cborTagDecimalFraction = 4
+)
+
+// MarshalCBOR encodes d as a CBOR decimal fraction (tag 4): an array of the
+// exponent and the mantissa. Mantissas that don't fit into 64 bits are
+// encoded as bignums (tags 2 and 3).
+// It is compatible with the cbor.Marshaler interface.
+func (d Decimal) MarshalCBOR() ([]byte, error) {
+	d.ensureInitialized()
+	b := cbor.AppendHead(nil, cbor.MajorTag, cborTagDecimalFraction)
+	b = cbor.AppendHead(b, cbor.MajorArray, 2)
+	b = appendCBORBigInt(b, big.NewInt(int64(d.exp)))
+	return appendCBORBigInt(b, d.value), nil
+}
+
+// UnmarshalCBOR decodes d from a CBOR decimal fraction (tag 4) or an integer.
+// It is compatible with the cbor.Unmarshaler interface.
+func (d *Decimal) UnmarshalCBOR(data []byte) error {
+	major, n, rest, err := readCBORHead(data)
+	if err != nil {
+		return err
+	}
+	var exp, value *big.Int
+	if major == cbor.MajorTag && n == cborTagDecimalFraction {
+		major, n, rest, err = readCBORHead(rest)
+		if err != nil {
+			return err
+		}
+		if major != cbor.MajorArray || n != 2 {
+			return fmt.Errorf("Error decoding CBOR decimal: expected an array of 2 items")
+		}
+		if exp, rest, err = readCBORBigInt(rest); err != nil {
+			return err
+		}
+		if !exp.IsInt64() || exp.Int64() < math.MinInt32 || exp.Int64() > math.MaxInt32 {
+			return fmt.Errorf("Error decoding CBOR decimal: exponent %s out of range", exp)
+		}
+		value, rest, err = readCBORBigInt(rest)
+	} else {
+		exp = new(big.Int)
+		value, rest, err = readCBORBigInt(data)
+	}
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return fmt.Errorf("Error decoding CBOR decimal: unexpected trailing data")
+	}
+	*d = Decimal{value: value, exp: int32(exp.Int64())}
+	return nil
+}
+
+// appendCBORBigInt appends v as a CBOR integer or, if it doesn't fit, as a bignum.
+func appendCBORBigInt(b []byte, v *big.Int) []byte {
+	major, tag := byte(cbor.MajorUint), uint64(cborTagPositiveBignum)
+	n := v
+	if v.Sign() < 0 {
+		// negative integers are encoded as -1 - n
+		major, tag = cbor.MajorNegInt, cborTagNegativeBignum
+		n = new(big.Int).Not(v)
+	}
+	if n.IsUint64() {
+		return cbor.AppendHead(b, major, n.Uint64())
+	}
+	bytes := n.Bytes()
+	b = cbor.AppendHead(b, cbor.MajorTag, tag)
+	b = cbor.AppendHead(b, cbor.MajorBytes, uint64(len(bytes)))
+	return append(b, bytes...)
+}
+
+// readCBORHead reads the head of a CBOR data item with cbor.ReadHead and
+// describes its errors.
+func readCBORHead(data []byte) (major byte, n uint64, rest []byte, err error) {
+	major, _, n, rest, err = cbor.ReadHead(data)
+	var e *cbor.Error
+	if !errors.As(err, &e) {
+		return major, n, rest, err
+	}
+	if e.Unsupported {
+		return 0, 0, nil, fmt.Errorf("Error decoding CBOR: unsupported initial byte 0x%02x", e.InitialByte)
+	}
+	return 0, 0, nil, fmt.Errorf("Error decoding CBOR: unexpected end of data")
+}
+
+// readCBORBigInt reads a CBOR integer or bignum.
+func readCBORBigInt(data []byte) (*big.Int, []byte, error) {
+	major, n, rest, err := readCBORHead(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch {
+	case major == cbor.MajorUint:
+		return new(big.Int).SetUint64(n), rest, nil
+	case major == cbor.MajorNegInt:
+		return new(big.Int).Not(new(big.Int).SetUint64(n)), rest, nil
+	case major == cbor.MajorTag && (n == cborTagPositiveBignum || n == cborTagNegativeBignum):
+		tag := n
+		major, n, rest, err = readCBORHead(rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		if major != cbor.MajorBytes || n > uint64(len(rest)) {
+			return nil, nil, fmt.Errorf("Error decoding CBOR: invalid bignum")
+		}
+		v := new(big.Int).SetBytes(rest[:n])
+		if tag == cborTagNegativeBignum {
+			v.Not(v)
+		}
+		return v, rest[n:], nil
+	default:
+		return nil, nil, fmt.Errorf("Error decoding CBOR: expected an integer")
+	}
+}
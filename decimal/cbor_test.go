@@ -0,0 +1,53 @@
+package decimal
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestCBOR(t *testing.T) {
+	for _, testCase := range []struct {
+		d   Decimal
+		hex string
+	}{
+		// 273.15, the example from RFC 8949
+		{New(27315, -2), "c48221196ab3"},
+		{New(-5, 3), "c4820324"},
+		{Decimal{}, "c4820000"},
+		{N("18446744073709551616"), "c48200c249010000000000000000"},
+		{N("-18446744073709551617"), "c48200c349010000000000000000"},
+		{N("-1.8446744073709551616"), "c482323bffffffffffffffff"},
+	} {
+		b, err := testCase.d.MarshalCBOR()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := hex.EncodeToString(b); s != testCase.hex {
+			t.Errorf("%s: expected %s, got %s", testCase.d, testCase.hex, s)
+		}
+		var d Decimal
+		if err := d.UnmarshalCBOR(b); err != nil {
+			t.Errorf("error decoding %s: %v", testCase.hex, err)
+		} else if !d.Equals(testCase.d) || d.exp != testCase.d.exp {
+			t.Errorf("expected %s, got %s", testCase.d, d)
+		}
+	}
+
+	for s, expected := range map[string]string{"1864": "100", "3863": "-100", "c249010000000000000000": "18446744073709551616"} {
+		data, _ := hex.DecodeString(s)
+		var d Decimal
+		if err := d.UnmarshalCBOR(data); err != nil {
+			t.Errorf("error decoding %s: %v", s, err)
+		} else if d.String() != expected {
+			t.Errorf("%s: expected %s, got %s", s, expected, d)
+		}
+	}
+
+	for _, s := range []string{"", "c4", "c48121", "c483210102", "c4821b000000010000000001", "c4822161", "c48221196ab300", "c2490100", "f6", "5f"} {
+		data, _ := hex.DecodeString(s)
+		var d Decimal
+		if err := d.UnmarshalCBOR(data); err == nil {
+			t.Errorf("%s: expected error, got %s", s, d)
+		}
+	}
+}
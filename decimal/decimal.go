@@ -19,6 +19,7 @@ package decimal
 
 import (
 	"database/sql/driver"
+	"encoding/binary"
 	"fmt"
 	"math"
 	"math/big"
@@ -83,7 +84,8 @@ func New(value int64, exp int32) Decimal {
 	}
 }
 
-// N is a convenience function for NewFromString(), it panics if value is invalid
+// N is a convenience function for NewFromString(), it panics if value is invalid.
+// Use NewFromString for input that may be invalid.
 func N(value string) Decimal {
 	d, err := NewFromString(value)
 	if err != nil {
@@ -102,7 +104,8 @@ func (d Decimal) P() *Decimal {
 	return &d
 }
 
-// SetStringPrecision sets the precision for string output in Marshaler interfaces
+// SetStringPrecision sets the precision for string output in Marshaler interfaces.
+// Use DecimalN to set the precision of a single value.
 func SetStringPrecision(value int32) {
 	stringPrecision = value
 }
@@ -163,19 +166,21 @@ func NewFromString(value string) (Decimal, error) {
 	}, nil
 }
 
-// NewFromFloat converts a float64 to Decimal.
+// NewFromFloat converts a float64 to Decimal. The result is the shortest
+// decimal that converts back to the same float64, use NewFromFloatExact to
+// get the exact binary value instead.
 //
 // Example:
 //
 //     NewFromFloat(123.45678901234567).String() // output: "123.4567890123456"
 //     NewFromFloat(.00000000000000001).String() // output: "0.00000000000000001"
 //
-// NOTE: this will panic on NaN, +/-inf
+// NOTE: this will panic on NaN, +/-inf. Use NewFromFloatE to get an error instead.
 func NewFromFloat(value float64) Decimal {
 	floor := math.Floor(value)
 
-	// fast path, where float is an int
-	if floor == value && !math.IsInf(value, 0) {
+	// fast path, where float is an int that fits into int64
+	if floor == value && math.Abs(value) < 1<<63 {
 		return New(int64(value), 0)
 	}
 
@@ -191,24 +196,18 @@ func NewFromFloat(value float64) Decimal {
 }
 
 // NewFromFloatWithExponent converts a float64 to Decimal, with an arbitrary
-// number of fractional digits.
+// number of fractional digits. The exact binary value of the float is rounded
+// half away from zero, so 1.005, which is stored as 1.00499999999999989...,
+// becomes 1.00 at exponent -2.
 //
 // Example:
 //
 //     NewFromFloatWithExponent(123.456, -2).String() // output: "123.46"
 //
+// NOTE: this will panic on NaN, +/-inf. Use NewFromFloatWithExponentE to get
+// an error instead.
 func NewFromFloatWithExponent(value float64, exp int32) Decimal {
-	mul := math.Pow(10, -float64(exp))
-	floatValue := value * mul
-	if math.IsNaN(floatValue) || math.IsInf(floatValue, 0) {
-		panic(fmt.Sprintf("Cannot create a Decimal from %v", floatValue))
-	}
-	dValue := big.NewInt(round(floatValue))
-
-	return Decimal{
-		value: dValue,
-		exp:   exp,
-	}
+	return NewFromFloatExact(value).RoundMode(-exp, RoundHalfUp)
 }
 
 // rescale returns a rescaled version of the decimal. Returned
@@ -286,7 +285,8 @@ func (d Decimal) Sub(d2 Decimal) Decimal {
 	}
 }
 
-// Mul returns d * d2.
+// Mul returns d * d2. It panics if the exponent of the result overflows an
+// int32, use MulE to get an error instead.
 func (d Decimal) Mul(d2 Decimal) Decimal {
 	d.ensureInitialized()
 	d2.ensureInitialized()
@@ -306,7 +306,8 @@ func (d Decimal) Mul(d2 Decimal) Decimal {
 }
 
 // Div returns d / d2. If it doesn't divide exactly, the result will have
-// DivisionPrecision digits after the decimal point.
+// DivisionPrecision digits after the decimal point. It panics if d2 is zero,
+// use DivE to get an error instead.
 func (d Decimal) Div(d2 Decimal) Decimal {
 	// NOTE(vadim): division is hard, use Rat to do it
 	ratNum := d.Rat()
@@ -346,7 +347,8 @@ func (d Decimal) Exponent() int32 {
 	return d.exp
 }
 
-// IntPart returns the integer component of the decimal.
+// IntPart returns the integer component of the decimal. It wraps around if
+// the integer component doesn't fit into an int64, use Int64 to detect that.
 func (d Decimal) IntPart() int64 {
 	scaledD := d.rescale(0)
 	return scaledD.value.Int64()
@@ -487,19 +489,13 @@ func (d Decimal) Truncate(precision int32) Decimal {
 	return d
 }
 
-// UnmarshalJSON implements the json.Unmarshaler interface.
+// UnmarshalJSON implements the json.Unmarshaler interface. The value is
+// decoded with JSONLenient, errors are *JSONError. Use StrictDecimal,
+// NullZeroDecimal or JSONDecoding.DecodeJSON for other rules.
 func (d *Decimal) UnmarshalJSON(decimalBytes []byte) error {
-	str, err := unquoteIfQuoted(decimalBytes)
-	if err != nil {
-		return fmt.Errorf("Error decoding string '%s': %s", decimalBytes, err)
-	}
-
-	decimal, err := NewFromString(str)
+	decimal, err := JSONLenient.DecodeJSON(decimalBytes)
 	*d = decimal
-	if err != nil {
-		return fmt.Errorf("Error decoding string '%s': %s", str, err)
-	}
-	return nil
+	return err
 }
 
 // MarshalJSON implements the json.Marshaler interface.
@@ -543,6 +539,60 @@ func (d Decimal) MarshalText() (text []byte, err error) {
 	return []byte(d.StringFixed(stringPrecision)), nil
 }
 
+// binaryVersion is the version of the binary format produced by MarshalBinary.
+const binaryVersion byte = 1
+
+// AppendBinary implements the encoding.BinaryAppender interface.
+//
+// The format is a version byte, the exponent as a zig-zag varint and
+// the coefficient in big.Int gob format (sign byte followed by the
+// big-endian absolute value). Unlike MarshalText, no precision is lost.
+func (d Decimal) AppendBinary(b []byte) ([]byte, error) {
+	d.ensureInitialized()
+	value, err := d.value.GobEncode()
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, binaryVersion)
+	b = binary.AppendVarint(b, int64(d.exp))
+	return append(b, value...), nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (d Decimal) MarshalBinary() ([]byte, error) {
+	return d.AppendBinary(nil)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (d *Decimal) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("Error decoding binary decimal: no data")
+	}
+	if data[0] != binaryVersion {
+		return fmt.Errorf("Error decoding binary decimal: unsupported version %d", data[0])
+	}
+	exp, size := binary.Varint(data[1:])
+	if size <= 0 || exp < math.MinInt32 || exp > math.MaxInt32 {
+		return fmt.Errorf("Error decoding binary decimal: invalid exponent")
+	}
+	value := new(big.Int)
+	if err := value.GobDecode(data[1+size:]); err != nil {
+		return fmt.Errorf("Error decoding binary decimal: %s", err)
+	}
+	*d = Decimal{value: value, exp: int32(exp)}
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface for gob serialization.
+func (d Decimal) GobEncode() ([]byte, error) {
+	return d.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface for gob serialization.
+func (d *Decimal) GobDecode(data []byte) error {
+	return d.UnmarshalBinary(data)
+}
+
 // NOTE: buggy, unintuitive, and DEPRECATED! Use StringFixed instead.
 // StringScaled first scales the decimal then calls .String() on it.
 func (d Decimal) StringScaled(exp int32) string {
@@ -641,13 +691,6 @@ func min(x, y int32) int32 {
 	return x
 }
 
-func round(n float64) int64 {
-	if n < 0 {
-		return int64(n - 0.5)
-	}
-	return int64(n + 0.5)
-}
-
 func unquoteIfQuoted(value interface{}) (string, error) {
 	bytes, ok := value.([]byte)
 	if !ok {
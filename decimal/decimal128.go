@@ -0,0 +1,93 @@
+package decimal
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Errors returned by the Decimal128 conversions.
+var (
+	// ErrDecimal128Overflow is returned when the magnitude of a decimal is too
+	// large for Decimal128.
+	ErrDecimal128Overflow = errors.New("decimal: value overflows Decimal128")
+
+	// ErrDecimal128Inexact is returned when a decimal has more significant
+	// digits than Decimal128 can hold and would have to be rounded.
+	ErrDecimal128Inexact = errors.New("decimal: value can't be represented exactly as Decimal128")
+
+	// ErrDecimal128NotFinite is returned when decoding a Decimal128 NaN or Infinity.
+	ErrDecimal128NotFinite = errors.New("decimal: Decimal128 value is not finite")
+)
+
+// BSON element types used by MarshalBSONValue and UnmarshalBSONValue.
+const (
+	bsonTypeInt32      = 0x10
+	bsonTypeInt64      = 0x12
+	bsonTypeDecimal128 = 0x13
+)
+
+// Decimal128 converts d to an IEEE 754-2008 decimal128 in the binary integer
+// decimal (BID) encoding used by BSON and returns its high and low 64 bits,
+// as expected by the MongoDB driver's NewDecimal128.
+//
+// Trailing zeros are dropped or added to fit the exponent range. If d has
+// more than 34 significant digits, ErrDecimal128Inexact is returned; if it is
+// too large, ErrDecimal128Overflow is returned. Use Decimal128Round to round
+// instead.
+func (d Decimal) Decimal128() (high, low uint64, err error) {
+	return d.decimal128(false)
+}
+
+// Decimal128Round is like Decimal128, but rounds d half to even to 34
+// significant digits. Values too small for decimal128 are rounded to zero.
+func (d Decimal) Decimal128Round() (high, low uint64, err error) {
+	return d.decimal128(true)
+}
+
+func (d Decimal) decimal128(round bool) (high, low uint64, err error) {
+	bits, err := decimal128Format.encode(d, round, false)
+	high, low = split128(bits)
+	return high, low, err
+}
+
+// NewFromDecimal128 returns a new Decimal from the high and low 64 bits of an
+// IEEE 754-2008 decimal128 in BID encoding. Non-canonical coefficients are
+// treated as zero, as required by the standard. NaN and Infinity return
+// ErrDecimal128NotFinite.
+func NewFromDecimal128(high, low uint64) (Decimal, error) {
+	return decimal128Format.decode(join128(high, low), false)
+}
+
+// MarshalBSONValue encodes d as a BSON Decimal128. It is compatible with the
+// bson.ValueMarshaler interface of the MongoDB driver.
+func (d Decimal) MarshalBSONValue() (byte, []byte, error) {
+	high, low, err := d.Decimal128()
+	if err != nil {
+		return 0, nil, err
+	}
+	data := make([]byte, 16)
+	binary.LittleEndian.PutUint64(data[:8], low)
+	binary.LittleEndian.PutUint64(data[8:], high)
+	return bsonTypeDecimal128, data, nil
+}
+
+// UnmarshalBSONValue decodes d from a BSON Decimal128, Int32 or Int64. It is
+// compatible with the bson.ValueUnmarshaler interface of the MongoDB driver.
+func (d *Decimal) UnmarshalBSONValue(typ byte, data []byte) error {
+	switch {
+	case typ == bsonTypeDecimal128 && len(data) == 16:
+		dec, err := NewFromDecimal128(binary.LittleEndian.Uint64(data[8:]), binary.LittleEndian.Uint64(data[:8]))
+		if err != nil {
+			return err
+		}
+		*d = dec
+	case typ == bsonTypeInt32 && len(data) == 4:
+		*d = New(int64(int32(binary.LittleEndian.Uint32(data))), 0)
+	case typ == bsonTypeInt64 && len(data) == 8:
+		*d = New(int64(binary.LittleEndian.Uint64(data)), 0)
+	default:
+		return fmt.Errorf("Error decoding BSON decimal: unsupported type 0x%02x with %d bytes", typ, len(data))
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package decimal
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestDecimal128(t *testing.T) {
+	// vectors from the BSON corpus of the MongoDB specifications
+	for _, testCase := range []struct {
+		d         Decimal
+		high, low uint64
+	}{
+		{Decimal{}, 0x3040000000000000, 0},
+		{New(1, 0), 0x3040000000000000, 1},
+		{New(-1, 0), 0xb040000000000000, 1},
+		{New(1, -1), 0x303e000000000000, 1},
+		{New(100, -2), 0x303c000000000000, 100},
+		{New(1, 3), 0x3046000000000000, 1},
+		{N("1234567890123456789012345678901234"), 0x30403cde6fff9732, 0xde825cd07e96aff2},
+		{N("9.999999999999999999999999999999999E-6143"), 0x0001ed09bead87c0, 0x378d8e63ffffffff},
+		{N("9.999999999999999999999999999999999E+6144"), 0x5fffed09bead87c0, 0x378d8e63ffffffff},
+		{N("1E-6176"), 0, 1},
+	} {
+		high, low, err := testCase.d.Decimal128()
+		if err != nil {
+			t.Errorf("error converting %s: %v", testCase.d, err)
+			continue
+		}
+		if high != testCase.high || low != testCase.low {
+			t.Errorf("%s: expected %016x %016x, got %016x %016x", testCase.d, testCase.high, testCase.low, high, low)
+		}
+		d, err := NewFromDecimal128(high, low)
+		if err != nil {
+			t.Errorf("error converting %016x %016x: %v", high, low, err)
+		} else if !d.Equals(testCase.d) || d.exp != testCase.d.exp {
+			t.Errorf("expected %s (exp %d), got %s (exp %d)", testCase.d, testCase.d.exp, d, d.exp)
+		}
+	}
+}
+
+func TestDecimal128Fit(t *testing.T) {
+	for _, testCase := range []struct {
+		d, expected string
+	}{
+		// exponents out of range are clamped by adding or dropping trailing zeros
+		{"1E+6144", "1000000000000000000000000000000000E+6111"},
+		{"1234567890123456789012345678901234000", "1234567890123456789012345678901234E+3"},
+		{"1000E-6179", "1E-6176"},
+	} {
+		high, low, err := N(testCase.d).Decimal128()
+		if err != nil {
+			t.Errorf("error converting %s: %v", testCase.d, err)
+			continue
+		}
+		d, err := NewFromDecimal128(high, low)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := N(testCase.expected); !d.Equals(expected) || d.exp != expected.exp {
+			t.Errorf("%s: expected %s (exp %d), got %s (exp %d)", testCase.d, expected, expected.exp, d, d.exp)
+		}
+	}
+
+	for _, testCase := range []struct {
+		d       string
+		err     error
+		rounded string
+	}{
+		{"12345678901234567890123456789012345", ErrDecimal128Inexact, "1234567890123456789012345678901234E+1"},
+		{"12345678901234567890123456789012355", ErrDecimal128Inexact, "1234567890123456789012345678901236E+1"},
+		{"99999999999999999999999999999999999", ErrDecimal128Inexact, "1000000000000000000000000000000000E+2"},
+		{"1.5E-6176", ErrDecimal128Inexact, "2E-6176"},
+		{"2.5E-6176", ErrDecimal128Inexact, "2E-6176"},
+		{"-1E-6177", ErrDecimal128Inexact, "0E-6176"},
+		{"1E-7000", ErrDecimal128Inexact, "0E-6176"},
+		{"1E+6145", ErrDecimal128Overflow, ""},
+		{"-1E+7000", ErrDecimal128Overflow, ""},
+	} {
+		d := N(testCase.d)
+		if _, _, err := d.Decimal128(); err != testCase.err {
+			t.Errorf("%s: expected %v, got %v", testCase.d, testCase.err, err)
+		}
+		high, low, err := d.Decimal128Round()
+		if testCase.rounded == "" {
+			if err != testCase.err {
+				t.Errorf("%s: expected %v, got %v", testCase.d, testCase.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("error rounding %s: %v", testCase.d, err)
+			continue
+		}
+		got, err := NewFromDecimal128(high, low)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := N(testCase.rounded); !got.Equals(expected) || got.exp != expected.exp {
+			t.Errorf("%s: expected %s, got %s (exp %d)", testCase.d, testCase.rounded, got, got.exp)
+		}
+	}
+}
+
+func TestNewFromDecimal128Special(t *testing.T) {
+	for _, high := range []uint64{0x7800000000000000, 0xf800000000000000, 0x7c00000000000000, 0x7e00000000000000} {
+		if d, err := NewFromDecimal128(high, 0); err != ErrDecimal128NotFinite {
+			t.Errorf("%016x: expected ErrDecimal128NotFinite, got %s, %v", high, d, err)
+		}
+	}
+	// non-canonical coefficients are zero
+	for _, testCase := range []struct {
+		high, low uint64
+		exp       int32
+	}{
+		{0x6c10000000000000, 0, 0},
+		{0x3041ed09bead87c0, 0x378d8e6400000000, 0},
+	} {
+		d, err := NewFromDecimal128(testCase.high, testCase.low)
+		if err != nil || d.value.Sign() != 0 || d.exp != testCase.exp {
+			t.Errorf("%016x %016x: expected 0, got %s (exp %d), %v", testCase.high, testCase.low, d, d.exp, err)
+		}
+	}
+}
+
+func TestBSONValue(t *testing.T) {
+	typ, data, err := New(-1, 0).MarshalBSONValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := hex.EncodeToString(data); typ != 0x13 || s != "0100000000000000000000000000"+"40b0" {
+		t.Errorf("expected 0x13 01000000000000000000000000000040b0, got 0x%02x %s", typ, s)
+	}
+	var d Decimal
+	if err := d.UnmarshalBSONValue(typ, data); err != nil || !d.Equals(New(-1, 0)) {
+		t.Errorf("expected -1, got %s, %v", d, err)
+	}
+
+	if err := d.UnmarshalBSONValue(0x10, []byte{0xfe, 0xff, 0xff, 0xff}); err != nil || d.String() != "-2" {
+		t.Errorf("expected -2, got %s, %v", d, err)
+	}
+	if err := d.UnmarshalBSONValue(0x12, []byte{0, 1, 0, 0, 0, 0, 0, 0}); err != nil || d.String() != "256" {
+		t.Errorf("expected 256, got %s, %v", d, err)
+	}
+	for _, testCase := range []struct {
+		typ  byte
+		data []byte
+	}{
+		{0x13, make([]byte, 15)},
+		{0x13, append(make([]byte, 15), 0x78)},
+		{0x01, make([]byte, 8)},
+		{0x10, make([]byte, 8)},
+	} {
+		if err := d.UnmarshalBSONValue(testCase.typ, testCase.data); err == nil {
+			t.Errorf("expected error for 0x%02x %x", testCase.typ, testCase.data)
+		}
+	}
+	if _, _, err := N("1E+7000").MarshalBSONValue(); err != ErrDecimal128Overflow {
+		t.Errorf("expected ErrDecimal128Overflow, got %v", err)
+	}
+}
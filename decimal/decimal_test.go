@@ -1,6 +1,8 @@
 package decimal
 
 import (
+	"bytes"
+	"encoding/gob"
 	"encoding/json"
 	"encoding/xml"
 	"math"
@@ -299,6 +301,78 @@ func TestBadXML(t *testing.T) {
 	}
 }
 
+func TestBinary(t *testing.T) {
+	for _, s := range testTable {
+		d, err := NewFromString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := d.MarshalBinary()
+		if err != nil {
+			t.Errorf("error marshaling %s: %v", s, err)
+			continue
+		}
+		var got Decimal
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Errorf("error unmarshaling %s: %v", s, err)
+		} else if got.String() != s || got.exp != d.exp {
+			t.Errorf("expected %s (exp %d), got %s (exp %d)", s, d.exp, got.String(), got.exp)
+		}
+	}
+
+	var zero Decimal
+	data, err := zero.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Decimal
+	if err := got.UnmarshalBinary(data); err != nil || !got.Equals(Zero) {
+		t.Errorf("expected 0, got %v, %v", got, err)
+	}
+}
+
+func TestBadBinary(t *testing.T) {
+	for _, testCase := range [][]byte{
+		nil,
+		{2, 0},
+		{binaryVersion},
+		{binaryVersion, 0xff, 0xff, 0xff, 0xff, 0xff},
+		{binaryVersion, 0, 4, 1},
+	} {
+		var d Decimal
+		if err := d.UnmarshalBinary(testCase); err == nil {
+			t.Errorf("expected error for %v, got %v", testCase, d)
+		}
+	}
+}
+
+func TestGob(t *testing.T) {
+	type account struct {
+		Amounts []Decimal
+		Limit   *Decimal
+	}
+	in := account{
+		Amounts: []Decimal{New(-12345, -3), N("1e100"), {}},
+		Limit:   New(5, 2).P(),
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	var out account
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Amounts) != len(in.Amounts) || out.Limit == nil || !out.Limit.Equals(*in.Limit) {
+		t.Fatalf("expected %+v, got %+v", in, out)
+	}
+	for i := range in.Amounts {
+		if !out.Amounts[i].Equals(in.Amounts[i]) {
+			t.Errorf("expected %s, got %s", in.Amounts[i], out.Amounts[i])
+		}
+	}
+}
+
 func TestDecimal_rescale(t *testing.T) {
 	type Inp struct {
 		int     int64
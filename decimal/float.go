@@ -0,0 +1,83 @@
+package decimal
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// NewFromFloatExact returns the exact value of the binary float64 value.
+// Every finite float64 has a finite decimal representation, though it may
+// be long.
+//
+// Example:
+//
+//	NewFromFloatExact(0.1).String() // output: "0.1000000000000000055511151231257827021181583404541015625"
+//	NewFromFloatExact(1e20).String() // output: "100000000000000000000"
+//
+// NOTE: this will panic on NaN, +/-inf.
+func NewFromFloatExact(value float64) Decimal {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		panic(fmt.Sprintf("Cannot create a Decimal from %v", value))
+	}
+	if value == 0 {
+		return New(0, 0)
+	}
+
+	// value = mant * 2^exp with an odd integer mant
+	frac, exp := math.Frexp(value)
+	mant := int64(math.Ldexp(frac, 53))
+	exp -= 53
+	for mant&1 == 0 {
+		mant >>= 1
+		exp++
+	}
+
+	if exp >= 0 {
+		return Decimal{value: new(big.Int).Lsh(big.NewInt(mant), uint(exp)), exp: 0}
+	}
+	// mant * 2^exp = mant * 5^-exp * 10^exp
+	five := new(big.Int).Exp(fiveInt, big.NewInt(int64(-exp)), nil)
+	return Decimal{value: five.Mul(five, big.NewInt(mant)), exp: int32(exp)}
+}
+
+// NewFromFloat32 converts a float32 to Decimal. The result is the shortest
+// decimal that converts back to the same float32, so NewFromFloat32(0.1) is
+// 0.1 rather than 0.100000001490116119384765625.
+//
+// NOTE: this will panic on NaN, +/-inf.
+func NewFromFloat32(value float32) Decimal {
+	f := float64(value)
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		panic(fmt.Sprintf("Cannot create a Decimal from %v", value))
+	}
+	dec, err := NewFromString(strconv.FormatFloat(f, 'f', -1, 32))
+	if err != nil {
+		panic(err) // this should never happen
+	}
+	return dec
+}
+
+// NewFromFloat32Exact returns the exact value of the binary float32 value.
+//
+// NOTE: this will panic on NaN, +/-inf.
+func NewFromFloat32Exact(value float32) Decimal {
+	return NewFromFloatExact(float64(value))
+}
+
+// NewFromFloat32WithExponent converts a float32 to Decimal, with an arbitrary
+// number of fractional digits. The exact binary value of the float is rounded
+// half away from zero.
+//
+// NOTE: this will panic on NaN, +/-inf.
+func NewFromFloat32WithExponent(value float32, exp int32) Decimal {
+	return NewFromFloatWithExponent(float64(value), exp)
+}
+
+// Float32 returns the nearest float32 value for d and a bool indicating
+// whether f represents d exactly.
+// For more details, see the documentation for big.Rat.Float32
+func (d Decimal) Float32() (f float32, exact bool) {
+	return d.Rat().Float32()
+}
@@ -0,0 +1,106 @@
+package decimal
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewFromFloatExact(t *testing.T) {
+	for _, testCase := range []struct {
+		f        float64
+		expected string
+	}{
+		{0, "0"},
+		{1, "1"},
+		{-2.5, "-2.5"},
+		{0.1, "0.1000000000000000055511151231257827021181583404541015625"},
+		{1.005, "1.00499999999999989341858963598497211933135986328125"},
+		{1e20, "100000000000000000000"},
+		{-1e23, "-99999999999999991611392"},
+	} {
+		d := NewFromFloatExact(testCase.f)
+		if expected := N(testCase.expected); d.Cmp(expected) != 0 {
+			t.Errorf("%v: expected %s, got %s", testCase.f, testCase.expected, d)
+		}
+		if f, exact := d.Float64(); !exact || f != testCase.f {
+			t.Errorf("%v: expected exact round trip, got %v, %v", testCase.f, f, exact)
+		}
+	}
+	if d := NewFromFloatExact(math.MaxFloat64); d.NumDigits() != 309 || d.exp != 0 {
+		t.Errorf("expected 309 digits, got %d (exp %d)", d.NumDigits(), d.exp)
+	}
+	if d := NewFromFloatExact(math.SmallestNonzeroFloat64); d.NumDigits() != 751 || d.exp != -1074 {
+		t.Errorf("expected 751 digits, got %d (exp %d)", d.NumDigits(), d.exp)
+	}
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if !didPanic(func() { NewFromFloatExact(f) }) {
+			t.Errorf("expected panic for %v", f)
+		}
+	}
+}
+
+func TestNewFromFloatShortest(t *testing.T) {
+	// large integers don't overflow int64
+	for _, testCase := range []struct {
+		f        float64
+		expected string
+	}{
+		{1e20, "100000000000000000000"},
+		{-1e23, "-100000000000000000000000"},
+		{1 << 63, "9223372036854776000"},
+		{-1 << 63, "-9223372036854776000"},
+	} {
+		if d := NewFromFloat(testCase.f); d.String() != testCase.expected {
+			t.Errorf("%v: expected %s, got %s", testCase.f, testCase.expected, d)
+		}
+	}
+}
+
+func TestNewFromFloatWithExponentRounding(t *testing.T) {
+	for _, testCase := range []struct {
+		f        float64
+		exp      int32
+		expected string
+	}{
+		{1.005, -2, "1"},
+		{1.015, -2, "1.01"},
+		{1.025, -2, "1.02"},
+		{2.5, 0, "3"},
+		{-2.5, 0, "-3"},
+		{0.125, -2, "0.13"},
+		{1e20, -2, "100000000000000000000"},
+	} {
+		d := NewFromFloatWithExponent(testCase.f, testCase.exp)
+		if d.String() != testCase.expected || d.exp != testCase.exp {
+			t.Errorf("%v at %d: expected %s, got %s (exp %d)", testCase.f, testCase.exp, testCase.expected, d, d.exp)
+		}
+	}
+	if d := NewFromFloatWithExponent(1e300, 299); d.Cmp(New(10, 299)) != 0 {
+		t.Errorf("expected 1E+300, got %s", d)
+	}
+}
+
+func TestFloat32(t *testing.T) {
+	if d := NewFromFloat32(0.1); d.String() != "0.1" {
+		t.Errorf("expected 0.1, got %s", d)
+	}
+	if d := NewFromFloat32(16777217); d.String() != "16777216" {
+		t.Errorf("expected 16777216, got %s", d)
+	}
+	if d := NewFromFloat32Exact(0.1); d.String() != "0.100000001490116119384765625" {
+		t.Errorf("expected 0.100000001490116119384765625, got %s", d)
+	}
+	// 2.675 is 2.6749999523162841796875 as float32
+	if d := NewFromFloat32WithExponent(2.675, -2); d.String() != "2.67" {
+		t.Errorf("expected 2.67, got %s", d)
+	}
+	if f, exact := N("0.1").Float32(); f != 0.1 || exact {
+		t.Errorf("expected inexact 0.1, got %v, %v", f, exact)
+	}
+	if f, exact := N("0.5").Float32(); f != 0.5 || !exact {
+		t.Errorf("expected exact 0.5, got %v, %v", f, exact)
+	}
+	if !didPanic(func() { NewFromFloat32(float32(math.Inf(1))) }) {
+		t.Error("expected panic for +Inf")
+	}
+}
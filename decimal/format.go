@@ -0,0 +1,215 @@
+package decimal
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// MinusStyle specifies how a NumberFormat writes negative numbers.
+type MinusStyle int
+
+const (
+	// MinusHyphen writes the ASCII hyphen-minus: "-1,234.00".
+	MinusHyphen MinusStyle = iota
+	// MinusSign writes the Unicode minus sign U+2212: "−1,234.00".
+	MinusSign
+	// MinusParentheses encloses the number in parentheses, as accounting
+	// reports do: "(1,234.00)".
+	MinusParentheses
+)
+
+// SymbolPlacement specifies where a NumberFormat writes the currency symbol.
+type SymbolPlacement int
+
+const (
+	// SymbolBefore writes the symbol before the number: "$1,234.00".
+	SymbolBefore SymbolPlacement = iota
+	// SymbolAfter writes the symbol after the number: "1 234,00 ₽".
+	SymbolAfter
+)
+
+// NumberFormat describes how decimals are written for people: separators,
+// digit grouping, negative numbers and the currency symbol. Use one of the
+// predefined formats or a modified copy of it:
+//
+//	f := *decimal.NumberFormatRU
+//	f.CurrencySymbol = "₽"
+//	f.Format(decimal.N("1234567.891")) // output: "1 234 567,89 ₽"
+type NumberFormat struct {
+	// DecimalSeparator separates the integer and the fractional parts.
+	DecimalSeparator string
+	// GroupSeparator separates groups of digits in the integer part.
+	GroupSeparator string
+	// Grouping lists group sizes from right to left; the last size repeats.
+	// {3} gives 1,234,567, {3, 2} gives the Indian 12,34,567, nil disables
+	// grouping.
+	Grouping []int
+	// Places is the number of fractional digits, the value is rounded half
+	// away from zero as by StringFixed. If Places is negative, all
+	// significant fractional digits are written as by String.
+	Places int32
+	// Minus is the style of negative numbers.
+	Minus MinusStyle
+	// CurrencySymbol is written with the number unless it is empty.
+	CurrencySymbol string
+	// SymbolPlacement is the position of the currency symbol.
+	SymbolPlacement SymbolPlacement
+	// SymbolSpace separates the currency symbol and the number with a space.
+	SymbolSpace bool
+}
+
+// NumberFormatRU is the Russian number format: "1 234 567,89".
+// Groups are separated by a space, set GroupSeparator to "\u00a0" to avoid
+// line breaks.
+var NumberFormatRU = &NumberFormat{
+	DecimalSeparator: ",",
+	GroupSeparator:   " ",
+	Grouping:         []int{3},
+	Places:           2,
+	Minus:            MinusHyphen,
+	SymbolPlacement:  SymbolAfter,
+	SymbolSpace:      true,
+}
+
+// NumberFormatEN is the English number format: "1,234,567.89".
+var NumberFormatEN = &NumberFormat{
+	DecimalSeparator: ".",
+	GroupSeparator:   ",",
+	Grouping:         []int{3},
+	Places:           2,
+	Minus:            MinusHyphen,
+	SymbolPlacement:  SymbolBefore,
+}
+
+// WithCurrency returns a copy of f with the currency symbol set to symbol.
+func (f *NumberFormat) WithCurrency(symbol string) *NumberFormat {
+	c := *f
+	c.CurrencySymbol = symbol
+	return &c
+}
+
+// Format returns d formatted according to f.
+//
+// Example:
+//
+//	NumberFormatEN.WithCurrency("$").Format(N("-1234567.891")) // output: "-$1,234,567.89"
+func (f *NumberFormat) Format(d Decimal) string {
+	var s string
+	if f.Places < 0 {
+		s = d.String()
+	} else {
+		s = d.StringFixed(f.Places)
+	}
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	number := f.group(intPart)
+	if fracPart != "" {
+		number += f.DecimalSeparator + fracPart
+	}
+
+	if f.CurrencySymbol != "" {
+		space := ""
+		if f.SymbolSpace {
+			space = " "
+		}
+		if f.SymbolPlacement == SymbolAfter {
+			number += space + f.CurrencySymbol
+		} else {
+			number = f.CurrencySymbol + space + number
+		}
+	}
+
+	if !negative {
+		return number
+	}
+	switch f.Minus {
+	case MinusSign:
+		return "−" + number
+	case MinusParentheses:
+		return "(" + number + ")"
+	default:
+		return "-" + number
+	}
+}
+
+// group inserts the group separator into the digits of the integer part.
+func (f *NumberFormat) group(digits string) string {
+	if len(f.Grouping) == 0 || f.GroupSeparator == "" {
+		return digits
+	}
+	var groups []string
+	for i := 0; len(digits) > 0; i++ {
+		size := f.Grouping[len(f.Grouping)-1]
+		if i < len(f.Grouping) {
+			size = f.Grouping[i]
+		}
+		if size <= 0 || size >= len(digits) {
+			groups = append(groups, digits)
+			break
+		}
+		groups = append(groups, digits[len(digits)-size:])
+		digits = digits[:len(digits)-size]
+	}
+	for i, j := 0, len(groups)-1; i < j; i, j = i+1, j-1 {
+		groups[i], groups[j] = groups[j], groups[i]
+	}
+	return strings.Join(groups, f.GroupSeparator)
+}
+
+// Parse parses a number formatted according to f. It is lenient: the
+// currency symbol is optional, any spaces (including no-break and thin
+// spaces) and group separators are ignored, and negative numbers may be
+// written with the hyphen-minus, the minus sign or in parentheses,
+// regardless of f.Minus.
+//
+// Example:
+//
+//	NumberFormatRU.Parse("-1 234 567,89 ₽") // output: -1234567.89
+func (f *NumberFormat) Parse(s string) (Decimal, error) {
+	original := s
+	s = strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = s[1 : len(s)-1]
+	}
+	if f.CurrencySymbol != "" {
+		s = strings.Replace(s, f.CurrencySymbol, "", 1)
+	}
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+	for _, minus := range []string{"-", "−"} {
+		if strings.HasPrefix(s, minus) && !negative {
+			negative = true
+			s = s[len(minus):]
+			break
+		}
+	}
+
+	if f.GroupSeparator != "" && strings.TrimSpace(f.GroupSeparator) != "" {
+		s = strings.ReplaceAll(s, f.GroupSeparator, "")
+	}
+	s = strings.Replace(s, f.DecimalSeparator, ".", 1)
+	if s == "" || strings.Trim(s, "0123456789.") != "" || strings.Count(s, ".") > 1 || s == "." {
+		return Decimal{}, fmt.Errorf("can't parse %q as a number", original)
+	}
+
+	d, err := NewFromString(s)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("can't parse %q as a number: %s", original, err)
+	}
+	if negative {
+		d = d.Neg()
+	}
+	return d, nil
+}
@@ -0,0 +1,97 @@
+package decimal
+
+import (
+	"testing"
+)
+
+func TestNumberFormat(t *testing.T) {
+	rub := NumberFormatRU.WithCurrency("₽")
+	usd := NumberFormatEN.WithCurrency("$")
+	accounting := NumberFormatEN.WithCurrency("$")
+	accounting.Minus = MinusParentheses
+	minus := *NumberFormatRU
+	minus.Minus = MinusSign
+	indian := &NumberFormat{DecimalSeparator: ".", GroupSeparator: ",", Grouping: []int{3, 2}, Places: -1}
+
+	for _, testCase := range []struct {
+		f        *NumberFormat
+		d        string
+		expected string
+	}{
+		{NumberFormatRU, "1234567.891", "1 234 567,89"},
+		{NumberFormatEN, "1234567.891", "1,234,567.89"},
+		{rub, "1234567.89", "1 234 567,89 ₽"},
+		{usd, "1234567.89", "$1,234,567.89"},
+		{usd, "-1234.5", "-$1,234.50"},
+		{rub, "-1234.5", "-1 234,50 ₽"},
+		{accounting, "-1234.5", "($1,234.50)"},
+		{accounting, "1234.5", "$1,234.50"},
+		{&minus, "-5", "−5,00"},
+		{NumberFormatEN, "0", "0.00"},
+		{NumberFormatEN, "-0.001", "0.00"},
+		{NumberFormatEN, "999.995", "1,000.00"},
+		{NumberFormatEN, "123", "123.00"},
+		{NumberFormatEN, "1234", "1,234.00"},
+		{indian, "1234567.125", "12,34,567.125"},
+		{indian, "100", "100"},
+		{&NumberFormat{DecimalSeparator: ","}, "1234567.5", "1234568"},
+	} {
+		d := N(testCase.d)
+		s := testCase.f.Format(d)
+		if s != testCase.expected {
+			t.Errorf("%s: expected %q, got %q", testCase.d, testCase.expected, s)
+			continue
+		}
+		parsed, err := testCase.f.Parse(s)
+		if err != nil {
+			t.Errorf("error parsing %q: %v", s, err)
+		} else if expected := d.Round(testCase.f.Places); testCase.f.Places >= 0 && !parsed.Equals(expected) {
+			t.Errorf("%q: expected %s, got %s", s, expected, parsed)
+		}
+	}
+	if NumberFormatRU.CurrencySymbol != "" {
+		t.Error("WithCurrency modified the predefined format")
+	}
+}
+
+func TestNumberFormatParse(t *testing.T) {
+	rub := NumberFormatRU.WithCurrency("₽")
+	for _, testCase := range []struct {
+		f        *NumberFormat
+		s        string
+		expected string
+	}{
+		{rub, "1 234 567,89 ₽", "1234567.89"},
+		{rub, "1 234 567,89 ₽", "1234567.89"},
+		{rub, "1 234,5", "1234.5"},
+		{rub, "  -1234,5₽ ", "-1234.5"},
+		{rub, "−1 234,5", "-1234.5"},
+		{rub, "(1 234,5 ₽)", "-1234.5"},
+		{NumberFormatRU, "1234.5", "1234.5"},
+		{NumberFormatEN, "$1,234.50", ""},
+		{NumberFormatEN.WithCurrency("$"), "$1,234.50", "1234.5"},
+		{NumberFormatEN.WithCurrency("$"), "-$1,234.50", "-1234.5"},
+		{NumberFormatEN.WithCurrency("$"), "($1,234.50)", "-1234.5"},
+		{NumberFormatEN, ".5", "0.5"},
+		{NumberFormatEN, "", ""},
+		{NumberFormatEN, "-", ""},
+		{NumberFormatEN, ".", ""},
+		{NumberFormatEN, "1.2.3", ""},
+		{NumberFormatEN, "1e5", ""},
+		{NumberFormatEN, "--1", ""},
+		{NumberFormatRU, "1,2,3", ""},
+	} {
+		d, err := testCase.f.Parse(testCase.s)
+		if testCase.expected == "" {
+			if err == nil {
+				t.Errorf("%q: expected error, got %s", testCase.s, d)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("error parsing %q: %v", testCase.s, err)
+		} else if !d.Equals(N(testCase.expected)) {
+			t.Errorf("%q: expected %s, got %s", testCase.s, testCase.expected, d)
+		}
+	}
+}
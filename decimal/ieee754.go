@@ -0,0 +1,391 @@
+package decimal
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/big"
+)
+
+// Errors returned by the Decimal64 conversions.
+var (
+	// ErrDecimal64Overflow is returned when the magnitude of a decimal is too
+	// large for Decimal64.
+	ErrDecimal64Overflow = errors.New("decimal: value overflows Decimal64")
+
+	// ErrDecimal64Inexact is returned when a decimal has more significant
+	// digits than Decimal64 can hold and would have to be rounded.
+	ErrDecimal64Inexact = errors.New("decimal: value can't be represented exactly as Decimal64")
+
+	// ErrDecimal64NotFinite is returned when decoding a Decimal64 NaN or Infinity.
+	ErrDecimal64NotFinite = errors.New("decimal: Decimal64 value is not finite")
+)
+
+// ieeeFormat describes an IEEE 754-2008 decimal interchange format.
+type ieeeFormat struct {
+	digits         int   // precision in decimal digits
+	minExp, maxExp int64 // range of the exponent of the integer coefficient
+	bias           int64
+	expBits        uint // width of the biased exponent
+	trailingBits   uint // width of the trailing significand field
+	errOverflow    error
+	errInexact     error
+	errNotFinite   error
+}
+
+var decimal64Format = ieeeFormat{
+	digits:       16,
+	minExp:       -398,
+	maxExp:       369,
+	bias:         398,
+	expBits:      10,
+	trailingBits: 50,
+	errOverflow:  ErrDecimal64Overflow,
+	errInexact:   ErrDecimal64Inexact,
+	errNotFinite: ErrDecimal64NotFinite,
+}
+
+var decimal128Format = ieeeFormat{
+	digits:       34,
+	minExp:       -6176,
+	maxExp:       6111,
+	bias:         6176,
+	expBits:      14,
+	trailingBits: 110,
+	errOverflow:  ErrDecimal128Overflow,
+	errInexact:   ErrDecimal128Inexact,
+	errNotFinite: ErrDecimal128NotFinite,
+}
+
+// Decimal64 converts d to an IEEE 754-2008 decimal64 in the binary integer
+// decimal (BID) encoding.
+//
+// Trailing zeros are dropped or added to fit the exponent range. If d has
+// more than 16 significant digits, ErrDecimal64Inexact is returned; if it is
+// too large, ErrDecimal64Overflow is returned. Use Decimal64Round to round
+// instead.
+func (d Decimal) Decimal64() (uint64, error) {
+	bits, err := decimal64Format.encode(d, false, false)
+	return bits.Uint64(), err
+}
+
+// Decimal64Round is like Decimal64, but rounds d half to even to 16
+// significant digits. Values too small for decimal64 are rounded to zero.
+func (d Decimal) Decimal64Round() (uint64, error) {
+	bits, err := decimal64Format.encode(d, true, false)
+	return bits.Uint64(), err
+}
+
+// NewFromDecimal64 returns a new Decimal from an IEEE 754-2008 decimal64 in
+// BID encoding. Non-canonical coefficients are treated as zero. NaN and
+// Infinity return ErrDecimal64NotFinite.
+func NewFromDecimal64(bits uint64) (Decimal, error) {
+	return decimal64Format.decode(new(big.Int).SetUint64(bits), false)
+}
+
+// Decimal64DPD is like Decimal64, but uses the densely packed decimal (DPD)
+// encoding.
+func (d Decimal) Decimal64DPD() (uint64, error) {
+	bits, err := decimal64Format.encode(d, false, true)
+	return bits.Uint64(), err
+}
+
+// Decimal64DPDRound is like Decimal64Round, but uses the densely packed
+// decimal (DPD) encoding.
+func (d Decimal) Decimal64DPDRound() (uint64, error) {
+	bits, err := decimal64Format.encode(d, true, true)
+	return bits.Uint64(), err
+}
+
+// NewFromDecimal64DPD returns a new Decimal from an IEEE 754-2008 decimal64
+// in densely packed decimal (DPD) encoding. NaN and Infinity return
+// ErrDecimal64NotFinite.
+func NewFromDecimal64DPD(bits uint64) (Decimal, error) {
+	return decimal64Format.decode(new(big.Int).SetUint64(bits), true)
+}
+
+// Decimal128DPD is like Decimal128, but uses the densely packed decimal (DPD)
+// encoding.
+func (d Decimal) Decimal128DPD() (high, low uint64, err error) {
+	bits, err := decimal128Format.encode(d, false, true)
+	high, low = split128(bits)
+	return high, low, err
+}
+
+// Decimal128DPDRound is like Decimal128Round, but uses the densely packed
+// decimal (DPD) encoding.
+func (d Decimal) Decimal128DPDRound() (high, low uint64, err error) {
+	bits, err := decimal128Format.encode(d, true, true)
+	high, low = split128(bits)
+	return high, low, err
+}
+
+// NewFromDecimal128DPD returns a new Decimal from the high and low 64 bits of
+// an IEEE 754-2008 decimal128 in densely packed decimal (DPD) encoding. NaN
+// and Infinity return ErrDecimal128NotFinite.
+func NewFromDecimal128DPD(high, low uint64) (Decimal, error) {
+	return decimal128Format.decode(join128(high, low), true)
+}
+
+// split128 returns the high and low 64 bits of a 128-bit value.
+func split128(bits *big.Int) (high, low uint64) {
+	var buf [16]byte
+	bits.FillBytes(buf[:])
+	return binary.BigEndian.Uint64(buf[:8]), binary.BigEndian.Uint64(buf[8:])
+}
+
+// join128 returns the 128-bit value with the given high and low 64 bits.
+func join128(high, low uint64) *big.Int {
+	var buf [16]byte
+	binary.BigEndian.PutUint64(buf[:8], high)
+	binary.BigEndian.PutUint64(buf[8:], low)
+	return new(big.Int).SetBytes(buf[:])
+}
+
+// size returns the width of the format in bits.
+func (f ieeeFormat) size() uint {
+	return 1 + f.expBits + 3 + f.trailingBits
+}
+
+// encode returns the bits of d in the format, in DPD encoding if dpd is true
+// and in BID encoding otherwise. On error the returned bits are zero.
+func (f ieeeFormat) encode(d Decimal, round, dpd bool) (*big.Int, error) {
+	coef, exp, err := d.fitIEEE(f.digits, f.minExp, f.maxExp, round)
+	if err == errIEEEInexact {
+		return new(big.Int), f.errInexact
+	} else if err == errIEEEOverflow {
+		return new(big.Int), f.errOverflow
+	}
+
+	k, t := f.size(), f.trailingBits
+	biasedExp := big.NewInt(exp + f.bias)
+	bits := new(big.Int)
+	switch {
+	case dpd:
+		// the combination field holds the two most significant bits of the
+		// exponent and the leading digit, the rest is in declets
+		declets := new(big.Int)
+		for i := uint(0); i < t/10; i++ {
+			q, r := new(big.Int).QuoRem(coef, big.NewInt(1000), new(big.Int))
+			declets.Or(declets, new(big.Int).Lsh(big.NewInt(int64(encodeDeclet(int(r.Int64())))), 10*i))
+			coef = q
+		}
+		leading, expTop := coef.Uint64(), new(big.Int).Rsh(biasedExp, f.expBits-2).Uint64()
+		var comb uint64
+		if leading < 8 {
+			comb = expTop<<3 | leading
+		} else {
+			comb = 0x18 | expTop<<1 | leading&1
+		}
+		expRest := new(big.Int).And(biasedExp, big.NewInt(1<<(f.expBits-2)-1))
+		bits.Lsh(new(big.Int).SetUint64(comb), k-6)
+		bits.Or(bits, expRest.Lsh(expRest, t))
+		bits.Or(bits, declets)
+	case coef.BitLen() <= int(t+3):
+		bits.Lsh(biasedExp, t+3)
+		bits.Or(bits, coef)
+	default:
+		// the coefficient has an implicit 100 prefix
+		bits.Lsh(big.NewInt(3), k-3)
+		bits.Or(bits, biasedExp.Lsh(biasedExp, t+1))
+		bits.Or(bits, coef.SetBit(coef, int(t+3), 0))
+	}
+	if d.value != nil && d.value.Sign() < 0 {
+		bits.SetBit(bits, int(k-1), 1)
+	}
+	return bits, nil
+}
+
+// decode returns the Decimal represented by bits in the format, in DPD
+// encoding if dpd is true and in BID encoding otherwise.
+func (f ieeeFormat) decode(bits *big.Int, dpd bool) (Decimal, error) {
+	k, t := f.size(), f.trailingBits
+	comb := new(big.Int).Rsh(bits, k-6).Uint64() & 0x1f
+	if comb>>1 == 0xf {
+		// 11110 is Infinity, 11111 is NaN
+		return Decimal{}, f.errNotFinite
+	}
+
+	var exp uint64
+	coef := new(big.Int)
+	switch {
+	case dpd:
+		var leading uint64
+		if comb>>3 == 3 {
+			exp, leading = comb>>1&3, 8|comb&1
+		} else {
+			exp, leading = comb>>3, comb&7
+		}
+		expRest := new(big.Int).Rsh(bits, t).Uint64() & (1<<(f.expBits-2) - 1)
+		exp = exp<<(f.expBits-2) | expRest
+		coef.SetUint64(leading)
+		for i := int(t/10) - 1; i >= 0; i-- {
+			declet := new(big.Int).Rsh(bits, uint(10*i)).Uint64() & 0x3ff
+			coef.Mul(coef, big.NewInt(1000))
+			coef.Add(coef, big.NewInt(int64(decodeDeclet(uint16(declet)))))
+		}
+	case comb>>3 == 3:
+		// the coefficient has an implicit 100 prefix
+		exp = new(big.Int).Rsh(bits, t+1).Uint64() & (1<<f.expBits - 1)
+		coef.And(bits, new(big.Int).Sub(new(big.Int).Lsh(oneInt, t+1), oneInt))
+		coef.SetBit(coef, int(t+3), 1)
+	default:
+		exp = new(big.Int).Rsh(bits, t+3).Uint64() & (1<<f.expBits - 1)
+		coef.And(bits, new(big.Int).Sub(new(big.Int).Lsh(oneInt, t+3), oneInt))
+	}
+
+	if coef.Cmp(powTen(f.digits)) >= 0 {
+		// non-canonical coefficients are zero
+		coef.SetInt64(0)
+	}
+	if bits.Bit(int(k-1)) == 1 {
+		coef.Neg(coef)
+	}
+	return Decimal{value: coef, exp: int32(int64(exp) - f.bias)}, nil
+}
+
+// encodeDeclet returns the densely packed decimal encoding of n in 0..999.
+// The digits abcd efgh ijkm are packed into pqr stu v wxy as in IEEE 754-2008.
+func encodeDeclet(n int) uint16 {
+	d1, d2, d3 := uint16(n/100), uint16(n/10%10), uint16(n%10)
+	bcd, fgh, jkm := d1&7, d2&7, d3&7
+	d, h, m := d1&1, d2&1, d3&1
+	switch d1>>3<<2 | d2>>3<<1 | d3>>3 {
+	case 0: // all digits are small
+		return bcd<<7 | fgh<<4 | jkm
+	case 1: // d3 is large
+		return bcd<<7 | fgh<<4 | 0x8 | m
+	case 2: // d2 is large
+		return bcd<<7 | jkm>>1<<5 | h<<4 | 0xa | m
+	case 3: // d2 and d3 are large
+		return bcd<<7 | 0x40 | h<<4 | 0xe | m
+	case 4: // d1 is large
+		return jkm>>1<<8 | d<<7 | fgh<<4 | 0xc | m
+	case 5: // d1 and d3 are large
+		return fgh>>1<<8 | d<<7 | 0x20 | h<<4 | 0xe | m
+	case 6: // d1 and d2 are large
+		return jkm>>1<<8 | d<<7 | h<<4 | 0xe | m
+	default: // all digits are large
+		return d<<7 | 0x60 | h<<4 | 0xe | m
+	}
+}
+
+// decodeDeclet returns the number in 0..999 encoded by the densely packed
+// decimal declet pqr stu v wxy. Non-canonical declets are decoded as the
+// standard requires.
+func decodeDeclet(declet uint16) int {
+	pqr, stu, wxy := declet>>7&7, declet>>4&7, declet&7
+	pq, st, r, u, y := pqr>>1, stu>>1, pqr&1, stu&1, wxy&1
+	var d1, d2, d3 uint16
+	switch {
+	case declet&0x8 == 0:
+		d1, d2, d3 = pqr, stu, wxy
+	case wxy>>1 == 0:
+		d1, d2, d3 = pqr, stu, 8|y
+	case wxy>>1 == 1:
+		d1, d2, d3 = pqr, 8|u, st<<1|y
+	case wxy>>1 == 2:
+		d1, d2, d3 = 8|r, stu, pq<<1|y
+	case st == 0:
+		d1, d2, d3 = 8|r, 8|u, pq<<1|y
+	case st == 1:
+		d1, d2, d3 = 8|r, pq<<1|u, 8|y
+	case st == 2:
+		d1, d2, d3 = pqr, 8|u, 8|y
+	default:
+		d1, d2, d3 = 8|r, 8|u, 8|y
+	}
+	return int(d1)*100 + int(d2)*10 + int(d3)
+}
+
+// errors of fitIEEE, converted to the errors of the particular format
+var (
+	errIEEEInexact  = errors.New("inexact")
+	errIEEEOverflow = errors.New("overflow")
+)
+
+// fitIEEE returns the absolute coefficient and the exponent of d that fit an
+// IEEE 754 decimal format with the given number of digits and exponent range.
+// If round is true, the coefficient is rounded half to even, otherwise
+// errIEEEInexact is returned when rounding is needed.
+func (d Decimal) fitIEEE(digits int, minExp, maxExp int64, round bool) (*big.Int, int64, error) {
+	d.ensureInitialized()
+	coef := new(big.Int).Abs(d.value)
+	exp := int64(d.exp)
+	if coef.Sign() == 0 {
+		return coef, min64(max64(exp, minExp), maxExp), nil
+	}
+
+	// drop the digits that don't fit into the coefficient or the exponent range
+	drop := int64(numDigits(coef) - digits)
+	if minExp-exp > drop {
+		drop = minExp - exp
+	}
+	if drop > 0 {
+		if drop > int64(numDigits(coef)) {
+			// all digits are dropped and the result rounds to zero
+			if !round {
+				return nil, 0, errIEEEInexact
+			}
+			return coef.SetInt64(0), minExp, nil
+		}
+		q, r := new(big.Int).QuoRem(coef, powTen(int(drop)), new(big.Int))
+		if r.Sign() != 0 {
+			if !round {
+				return nil, 0, errIEEEInexact
+			}
+			// round half to even
+			if c := new(big.Int).Lsh(r, 1).Cmp(powTen(int(drop))); c > 0 || c == 0 && q.Bit(0) == 1 {
+				q.Add(q, oneInt)
+			}
+		}
+		coef, exp = q, exp+drop
+		if numDigits(coef) > digits {
+			coef.Quo(coef, tenInt)
+			exp++
+		}
+	}
+
+	// move the exponent into the range by adding trailing zeros
+	if exp > maxExp {
+		shift := exp - maxExp
+		if int64(numDigits(coef))+shift > int64(digits) {
+			return nil, 0, errIEEEOverflow
+		}
+		coef.Mul(coef, powTen(int(shift)))
+		exp = maxExp
+	}
+	return coef, exp, nil
+}
+
+// numDigits returns the number of decimal digits in the absolute value of x.
+func numDigits(x *big.Int) int {
+	if x.Sign() == 0 {
+		return 1
+	}
+	// the estimate by the bit length is off by at most one
+	n := int(float64(x.BitLen()-1)*math.Log10(2)) + 1
+	if new(big.Int).Abs(x).Cmp(powTen(n)) >= 0 {
+		n++
+	}
+	return n
+}
+
+// powTen returns 10^n.
+func powTen(n int) *big.Int {
+	return new(big.Int).Exp(tenInt, big.NewInt(int64(n)), nil)
+}
+
+func min64(x, y int64) int64 {
+	if x < y {
+		return x
+	}
+	return y
+}
+
+func max64(x, y int64) int64 {
+	if x > y {
+		return x
+	}
+	return y
+}
@@ -0,0 +1,192 @@
+package decimal
+
+import (
+	"testing"
+)
+
+func TestDeclet(t *testing.T) {
+	// examples from IEEE 754-2008, table 3.3
+	for _, testCase := range []struct {
+		n      int
+		declet uint16
+	}{
+		{5, 0x005},
+		{9, 0x009},
+		{55, 0x055},
+		{79, 0x079},
+		{80, 0x00a},
+		{99, 0x05f},
+		{555, 0x2d5},
+		{999, 0x0ff},
+	} {
+		if declet := encodeDeclet(testCase.n); declet != testCase.declet {
+			t.Errorf("%03d: expected 0x%03x, got 0x%03x", testCase.n, testCase.declet, declet)
+		}
+	}
+	for n := 0; n < 1000; n++ {
+		if got := decodeDeclet(encodeDeclet(n)); got != n {
+			t.Errorf("expected %03d, got %03d", n, got)
+		}
+	}
+	// non-canonical declets of 888 and 999
+	for _, declet := range []uint16{0x16e, 0x26e, 0x36e, 0x1ff, 0x2ff, 0x3ff} {
+		expected := 888
+		if declet&0xff == 0xff {
+			expected = 999
+		}
+		if n := decodeDeclet(declet); n != expected {
+			t.Errorf("0x%03x: expected %d, got %d", declet, expected, n)
+		}
+	}
+}
+
+func TestDecimal64(t *testing.T) {
+	for _, testCase := range []struct {
+		d        Decimal
+		bid, dpd uint64
+	}{
+		{Decimal{}, 0x31c0000000000000, 0x2238000000000000},
+		{New(1, 0), 0x31c0000000000001, 0x2238000000000001},
+		{New(-1, 0), 0xb1c0000000000001, 0xa238000000000001},
+		{New(-750, -2), 0xb1800000000002ee, 0xa2300000000003d0},
+		{N("9999999999999999"), 0x6c7386f26fc0ffff, 0x6e38ff3fcff3fcff},
+		{N("9.999999999999999E+384"), 0x77fb86f26fc0ffff, 0x77fcff3fcff3fcff},
+		{N("1E-398"), 0x0000000000000001, 0x0000000000000001},
+	} {
+		bid, err := testCase.d.Decimal64()
+		if err != nil {
+			t.Errorf("error converting %s: %v", testCase.d, err)
+			continue
+		}
+		if bid != testCase.bid {
+			t.Errorf("%s: expected BID %016x, got %016x", testCase.d, testCase.bid, bid)
+		}
+		dpd, err := testCase.d.Decimal64DPD()
+		if err != nil {
+			t.Errorf("error converting %s: %v", testCase.d, err)
+			continue
+		}
+		if dpd != testCase.dpd {
+			t.Errorf("%s: expected DPD %016x, got %016x", testCase.d, testCase.dpd, dpd)
+		}
+
+		for _, d := range []Decimal{mustDecimal(NewFromDecimal64(testCase.bid)), mustDecimal(NewFromDecimal64DPD(testCase.dpd))} {
+			if !d.Equals(testCase.d) || d.exp != testCase.d.exp {
+				t.Errorf("expected %s (exp %d), got %s (exp %d)", testCase.d, testCase.d.exp, d, d.exp)
+			}
+		}
+	}
+}
+
+func TestDecimal64Fit(t *testing.T) {
+	for _, testCase := range []struct {
+		d       string
+		err     error
+		rounded string
+	}{
+		{"12345678901234567", ErrDecimal64Inexact, "1234567890123457E+1"},
+		{"99999999999999995", ErrDecimal64Inexact, "1000000000000000E+2"},
+		{"2.5E-398", ErrDecimal64Inexact, "2E-398"},
+		{"1E-500", ErrDecimal64Inexact, "0E-398"},
+		{"1E+385", ErrDecimal64Overflow, ""},
+	} {
+		d := N(testCase.d)
+		if _, err := d.Decimal64(); err != testCase.err {
+			t.Errorf("%s: expected %v, got %v", testCase.d, testCase.err, err)
+		}
+		if _, err := d.Decimal64DPD(); err != testCase.err {
+			t.Errorf("%s: expected %v, got %v", testCase.d, testCase.err, err)
+		}
+		bid, err := d.Decimal64Round()
+		dpd, errDPD := d.Decimal64DPDRound()
+		if testCase.rounded == "" {
+			if err != testCase.err || errDPD != testCase.err {
+				t.Errorf("%s: expected %v, got %v and %v", testCase.d, testCase.err, err, errDPD)
+			}
+			continue
+		}
+		if err != nil || errDPD != nil {
+			t.Errorf("error rounding %s: %v, %v", testCase.d, err, errDPD)
+			continue
+		}
+		expected := N(testCase.rounded)
+		for _, got := range []Decimal{mustDecimal(NewFromDecimal64(bid)), mustDecimal(NewFromDecimal64DPD(dpd))} {
+			if !got.Equals(expected) || got.exp != expected.exp {
+				t.Errorf("%s: expected %s, got %s (exp %d)", testCase.d, testCase.rounded, got, got.exp)
+			}
+		}
+	}
+
+	// 1E+384 fits by adding trailing zeros to the coefficient
+	bits, err := N("1E+384").Decimal64()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := mustDecimal(NewFromDecimal64(bits)); d.String() != N("1E+384").String() || d.exp != 369 {
+		t.Errorf("expected 1E+384 with exp 369, got %s (exp %d)", d, d.exp)
+	}
+}
+
+func TestNewFromDecimal64Special(t *testing.T) {
+	for _, bits := range []uint64{0x7800000000000000, 0xf800000000000000, 0x7c00000000000000, 0xfe00000000000000} {
+		if d, err := NewFromDecimal64(bits); err != ErrDecimal64NotFinite {
+			t.Errorf("%016x: expected ErrDecimal64NotFinite, got %s, %v", bits, d, err)
+		}
+		if d, err := NewFromDecimal64DPD(bits); err != ErrDecimal64NotFinite {
+			t.Errorf("%016x: expected ErrDecimal64NotFinite, got %s, %v", bits, d, err)
+		}
+	}
+	// non-canonical BID coefficients are zero
+	if d, err := NewFromDecimal64(0x6c7fffffffffffff); err != nil || d.value.Sign() != 0 {
+		t.Errorf("expected 0, got %s, %v", d, err)
+	}
+}
+
+func TestDecimal128DPD(t *testing.T) {
+	for _, testCase := range []struct {
+		d         Decimal
+		high, low uint64
+	}{
+		{New(1, 0), 0x2208000000000000, 0x0000000000000001},
+		{New(-1, 0), 0xa208000000000000, 0x0000000000000001},
+		{New(1, -1), 0x2207c00000000000, 0x0000000000000001},
+		{N("9999999999999999999999999999999999"), 0x6e080ff3fcff3fcf, 0xf3fcff3fcff3fcff},
+		{N("1E-6176"), 0, 1},
+	} {
+		high, low, err := testCase.d.Decimal128DPD()
+		if err != nil {
+			t.Errorf("error converting %s: %v", testCase.d, err)
+			continue
+		}
+		if high != testCase.high || low != testCase.low {
+			t.Errorf("%s: expected %016x %016x, got %016x %016x", testCase.d, testCase.high, testCase.low, high, low)
+		}
+		d, err := NewFromDecimal128DPD(high, low)
+		if err != nil {
+			t.Errorf("error converting %016x %016x: %v", high, low, err)
+		} else if !d.Equals(testCase.d) || d.exp != testCase.d.exp {
+			t.Errorf("expected %s (exp %d), got %s (exp %d)", testCase.d, testCase.d.exp, d, d.exp)
+		}
+	}
+
+	if _, _, err := N("1E+6145").Decimal128DPD(); err != ErrDecimal128Overflow {
+		t.Errorf("expected ErrDecimal128Overflow, got %v", err)
+	}
+	high, low, err := N("12345678901234567890123456789012345").Decimal128DPDRound()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := mustDecimal(NewFromDecimal128DPD(high, low)); d.String() != "12345678901234567890123456789012340" {
+		t.Errorf("expected 12345678901234567890123456789012340, got %s", d)
+	}
+	if _, err := NewFromDecimal128DPD(0x7c00000000000000, 0); err != ErrDecimal128NotFinite {
+		t.Errorf("expected ErrDecimal128NotFinite, got %v", err)
+	}
+}
+
+func mustDecimal(d Decimal, err error) Decimal {
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
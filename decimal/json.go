@@ -0,0 +1,193 @@
+package decimal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors wrapped by JSONError. Use errors.Is to check for them.
+var (
+	// ErrJSONEmpty is returned for empty input and the empty string "".
+	ErrJSONEmpty = errors.New("decimal: empty JSON value")
+	// ErrJSONNull is returned for null unless JSONDecoding.NullAsZero is set.
+	ErrJSONNull = errors.New("decimal: JSON null")
+	// ErrJSONQuoted is returned for a number in a JSON string when
+	// JSONDecoding.RejectQuoted is set.
+	ErrJSONQuoted = errors.New("decimal: quoted JSON number")
+	// ErrJSONExponent is returned for a number with an exponent when
+	// JSONDecoding.RejectExponent is set.
+	ErrJSONExponent = errors.New("decimal: JSON number with exponent")
+	// ErrJSONSyntax is returned for a value that isn't a number, such as
+	// true, an object or a malformed number.
+	ErrJSONSyntax = errors.New("decimal: invalid JSON number")
+)
+
+// JSONError describes a JSON value that can't be decoded to a Decimal.
+type JSONError struct {
+	// Value is the JSON value as it was passed to UnmarshalJSON.
+	Value string
+	// Err is or wraps one of the ErrJSON errors.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *JSONError) Error() string {
+	return fmt.Sprintf("Error decoding JSON value '%s': %s", e.Value, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *JSONError) Unwrap() error {
+	return e.Err
+}
+
+// JSONDecoding configures how JSON values are decoded to decimals.
+type JSONDecoding struct {
+	// RejectQuoted accepts only JSON numbers, not numbers in strings.
+	RejectQuoted bool
+	// RejectExponent rejects numbers with an exponent such as 1e3.
+	RejectExponent bool
+	// NullAsZero decodes null to zero instead of returning ErrJSONNull.
+	NullAsZero bool
+}
+
+// JSONLenient accepts JSON numbers and numbers in JSON strings written in
+// any form NewFromString understands. Decimal.UnmarshalJSON uses it.
+var JSONLenient = JSONDecoding{}
+
+// JSONStrict accepts only plain JSON numbers without an exponent.
+// StrictDecimal.UnmarshalJSON uses it.
+var JSONStrict = JSONDecoding{RejectQuoted: true, RejectExponent: true}
+
+// JSONNullAsZero is JSONLenient that also decodes null to zero instead of
+// returning ErrJSONNull. NullZeroDecimal.UnmarshalJSON uses it.
+var JSONNullAsZero = JSONDecoding{NullAsZero: true}
+
+// StrictDecimal is a Decimal decoded from JSON with JSONStrict, so a struct
+// field can reject quoted numbers and exponents while other fields accept
+// them:
+//
+//	var order struct {
+//	    Amount decimal.StrictDecimal `json:"amount"` // 1.5 only
+//	    Fee    decimal.Decimal       `json:"fee"`    // 1.5, "1.5" or 15e-1
+//	}
+type StrictDecimal struct {
+	Decimal
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, errors are
+// *JSONError.
+func (d *StrictDecimal) UnmarshalJSON(data []byte) error {
+	decimal, err := JSONStrict.DecodeJSON(data)
+	d.Decimal = decimal
+	return err
+}
+
+// NullZeroDecimal is a Decimal decoded from JSON with JSONNullAsZero, so a
+// null field is zero instead of an error.
+type NullZeroDecimal struct {
+	Decimal
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, errors are
+// *JSONError.
+func (d *NullZeroDecimal) UnmarshalJSON(data []byte) error {
+	decimal, err := JSONNullAsZero.DecodeJSON(data)
+	d.Decimal = decimal
+	return err
+}
+
+// DecodeJSON decodes the JSON value data according to o. All errors are
+// *JSONError.
+//
+// Example:
+//
+//	decimal.JSONStrict.DecodeJSON([]byte(`"1.5"`)) // error: quoted JSON number
+func (o JSONDecoding) DecodeJSON(data []byte) (Decimal, error) {
+	d, err := o.decodeJSON(strings.TrimSpace(string(data)))
+	if err != nil {
+		return Decimal{}, &JSONError{Value: string(data), Err: err}
+	}
+	return d, nil
+}
+
+func (o JSONDecoding) decodeJSON(s string) (Decimal, error) {
+	switch {
+	case s == "":
+		return Decimal{}, ErrJSONEmpty
+	case s == "null":
+		if o.NullAsZero {
+			return New(0, 0), nil
+		}
+		return Decimal{}, ErrJSONNull
+	case s[0] == '"':
+		if len(s) < 2 || s[len(s)-1] != '"' || strings.ContainsAny(s[1:len(s)-1], `"\`) {
+			return Decimal{}, ErrJSONSyntax
+		}
+		if o.RejectQuoted {
+			return Decimal{}, ErrJSONQuoted
+		}
+		s = s[1 : len(s)-1]
+		if s == "" {
+			return Decimal{}, ErrJSONEmpty
+		}
+	case !isJSONNumber(s):
+		return Decimal{}, ErrJSONSyntax
+	}
+	if o.RejectExponent && strings.ContainsAny(s, "eE") {
+		return Decimal{}, ErrJSONExponent
+	}
+	d, err := NewFromString(s)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("%w: %s", ErrJSONSyntax, err)
+	}
+	return d, nil
+}
+
+// isJSONNumber reports whether s matches the JSON number grammar
+// -?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?.
+func isJSONNumber(s string) bool {
+	digits := func() int {
+		n := 0
+		for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+			n++
+		}
+		s = s[n:]
+		return n
+	}
+
+	s = strings.TrimPrefix(s, "-")
+	if strings.HasPrefix(s, "0") {
+		s = s[1:]
+	} else if digits() == 0 {
+		return false
+	}
+	if strings.HasPrefix(s, ".") {
+		s = s[1:]
+		if digits() == 0 {
+			return false
+		}
+	}
+	if strings.HasPrefix(s, "e") || strings.HasPrefix(s, "E") {
+		s = s[1:]
+		if strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-") {
+			s = s[1:]
+		}
+		if digits() == 0 {
+			return false
+		}
+	}
+	return s == ""
+}
+
+// NewFromJSONNumber returns a new Decimal from a json.Number, as produced by
+// a json.Decoder with UseNumber.
+func NewFromJSONNumber(n json.Number) (Decimal, error) {
+	return JSONLenient.DecodeJSON([]byte(n))
+}
+
+// JSONNumber returns d as a json.Number with all significant digits.
+func (d Decimal) JSONNumber() json.Number {
+	return json.Number(d.String())
+}
@@ -0,0 +1,133 @@
+package decimal
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	nullAsZero := JSONDecoding{NullAsZero: true}
+	for _, testCase := range []struct {
+		o        JSONDecoding
+		s        string
+		expected string
+		err      error
+	}{
+		{JSONLenient, "1.5", "1.5", nil},
+		{JSONLenient, ` -0.25 `, "-0.25", nil},
+		{JSONLenient, `"1.5"`, "1.5", nil},
+		{JSONLenient, `"1."`, "1", nil},
+		{JSONLenient, "1e3", "1000", nil},
+		{JSONLenient, `"1e3"`, "1000", nil},
+		{JSONLenient, "", "", ErrJSONEmpty},
+		{JSONLenient, `""`, "", ErrJSONEmpty},
+		{JSONLenient, "null", "", ErrJSONNull},
+		{nullAsZero, "null", "0", nil},
+		{JSONLenient, "true", "", ErrJSONSyntax},
+		{JSONLenient, "{}", "", ErrJSONSyntax},
+		{JSONLenient, `"`, "", ErrJSONSyntax},
+		{JSONLenient, `"1`, "", ErrJSONSyntax},
+		{JSONLenient, `"1\"2"`, "", ErrJSONSyntax},
+		{JSONLenient, "01", "", ErrJSONSyntax},
+		{JSONLenient, "+1", "", ErrJSONSyntax},
+		{JSONLenient, "1.", "", ErrJSONSyntax},
+		{JSONLenient, ".5", "", ErrJSONSyntax},
+		{JSONLenient, "1e", "", ErrJSONSyntax},
+		{JSONLenient, "-", "", ErrJSONSyntax},
+		{JSONLenient, "0x10", "", ErrJSONSyntax},
+		{JSONStrict, "1.5", "1.5", nil},
+		{JSONStrict, `"1.5"`, "", ErrJSONQuoted},
+		{JSONStrict, "1e3", "", ErrJSONExponent},
+		{JSONStrict, "1E-3", "", ErrJSONExponent},
+		{JSONStrict, "null", "", ErrJSONNull},
+	} {
+		d, err := testCase.o.DecodeJSON([]byte(testCase.s))
+		if testCase.err != nil {
+			var jsonErr *JSONError
+			if !errors.Is(err, testCase.err) || !errors.As(err, &jsonErr) || jsonErr.Value != testCase.s {
+				t.Errorf("%q: expected %v, got %v", testCase.s, testCase.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", testCase.s, err)
+		} else if !d.Equals(N(testCase.expected)) {
+			t.Errorf("%q: expected %s, got %s", testCase.s, testCase.expected, d)
+		}
+	}
+
+	_, err := JSONLenient.DecodeJSON([]byte(`"nope"`))
+	var jsonErr *JSONError
+	if !errors.As(err, &jsonErr) || !errors.Is(err, ErrJSONSyntax) || !strings.Contains(err.Error(), `"nope"`) {
+		t.Errorf("expected JSONError, got %v", err)
+	}
+}
+
+func TestJSONDecodingPerField(t *testing.T) {
+	var doc struct {
+		Amount   Decimal         `json:"amount"`
+		Strict   StrictDecimal   `json:"strict"`
+		NullZero NullZeroDecimal `json:"nullzero"`
+	}
+	if err := json.Unmarshal([]byte(`{"amount":"1.5","strict":2.5,"nullzero":"3.5"}`), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if !doc.Amount.Equals(N("1.5")) || !doc.Strict.Equals(N("2.5")) || !doc.NullZero.Equals(N("3.5")) {
+		t.Errorf("expected 1.5, 2.5 and 3.5, got %s, %s and %s", doc.Amount, doc.Strict, doc.NullZero)
+	}
+
+	for _, testCase := range []struct {
+		s   string
+		err error
+	}{
+		{`{"amount":null}`, ErrJSONNull},
+		{`{"strict":"1.5"}`, ErrJSONQuoted},
+		{`{"strict":1e3}`, ErrJSONExponent},
+		{`{"strict":null}`, ErrJSONNull},
+		{`{"nullzero":"1,5"}`, ErrJSONSyntax},
+	} {
+		if err := json.Unmarshal([]byte(testCase.s), &doc); !errors.Is(err, testCase.err) {
+			t.Errorf("%s: expected %v, got %v", testCase.s, testCase.err, err)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(`{"nullzero":null}`), &doc); err != nil || !doc.NullZero.Equals(Zero) {
+		t.Errorf("expected 0, got %s (%v)", doc.NullZero, err)
+	}
+	if d, err := JSONNullAsZero.DecodeJSON([]byte("null")); err != nil || !d.Equals(Zero) {
+		t.Errorf("expected 0, got %s (%v)", d, err)
+	}
+	if _, err := JSONLenient.DecodeJSON([]byte("null")); !errors.Is(err, ErrJSONNull) {
+		t.Errorf("expected ErrJSONNull, got %v", err)
+	}
+	if b, err := json.Marshal(doc.Strict); err != nil || string(b) != doc.Strict.StringFixed(stringPrecision) {
+		t.Errorf("expected StrictDecimal to marshal as Decimal, got %s (%v)", b, err)
+	}
+}
+
+func TestJSONNumber(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`{"amount":123456789012345678901234567890.125}`))
+	dec.UseNumber()
+	var doc map[string]interface{}
+	if err := dec.Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+	d, err := NewFromJSONNumber(doc["amount"].(json.Number))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "123456789012345678901234567890.125"; d.String() != expected {
+		t.Errorf("expected %s, got %s", expected, d)
+	}
+	if n := d.JSONNumber(); n.String() != "123456789012345678901234567890.125" {
+		t.Errorf("expected %s, got %s", d, n)
+	}
+	if n := N("1.500").JSONNumber(); n != "1.5" {
+		t.Errorf("expected 1.5, got %s", n)
+	}
+	if _, err := NewFromJSONNumber("abc"); !errors.Is(err, ErrJSONSyntax) {
+		t.Errorf("expected ErrJSONSyntax, got %v", err)
+	}
+}
@@ -0,0 +1,238 @@
+package decimal
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MarshalStyle selects how DecimalN is written by MarshalJSON and MarshalText.
+type MarshalStyle int
+
+const (
+	// MarshalDefault writes the decimal as Decimal does, with the number of
+	// places set by SetStringPrecision.
+	MarshalDefault MarshalStyle = iota
+	// MarshalFixed writes exactly Places decimal places, rounding with
+	// Rounding.
+	MarshalFixed
+	// MarshalExact writes all significant digits without rounding, as String.
+	MarshalExact
+)
+
+// DecimalN is a Decimal that carries its own marshaling format, so a struct
+// can hold values of different precision, e.g. a price with 2 places and an
+// exchange rate with 6 places. The zero value marshals as Decimal.
+//
+// Unmarshaling and Scan set only the Decimal and keep the format, so the
+// fields of a decoded struct should be initialized with the format, either
+// by hand or from `decimal` struct tags with SetFormats:
+//
+//	type Quote struct {
+//	    Price decimal.DecimalN
+//	    Rate  decimal.DecimalN
+//	}
+//
+//	q := Quote{
+//	    Price: decimal.NewDecimalN(decimal.N("12.345"), 2, decimal.RoundHalfEven),
+//	    Rate:  decimal.NewDecimalExact(decimal.N("0.0123456789")).Quote(),
+//	}
+//	json.Marshal(q) // output: {"Price":12.34,"Rate":"0.0123456789"}
+type DecimalN struct {
+	Decimal
+	// Style selects how the decimal is written.
+	Style MarshalStyle
+	// Places is the number of decimal places written with MarshalFixed.
+	// Like Round, a negative Places rounds to tens, hundreds and so on.
+	Places int32
+	// Rounding is the rounding mode used with MarshalFixed.
+	Rounding RoundingMode
+	// Exact makes MarshalFixed fail with ErrNumericInexact instead of
+	// rounding with Rounding.
+	Exact bool
+	// Quoted writes JSON strings instead of numbers, for clients such as
+	// JavaScript that parse numbers as float64 and lose precision.
+	Quoted bool
+}
+
+// NewDecimalN returns d marshaled with exactly places decimal places,
+// rounded with mode.
+func NewDecimalN(d Decimal, places int32, mode RoundingMode) DecimalN {
+	return DecimalN{Decimal: d, Style: MarshalFixed, Places: places, Rounding: mode}
+}
+
+// NewDecimalExact returns d marshaled with all significant digits.
+func NewDecimalExact(d Decimal) DecimalN {
+	return DecimalN{Decimal: d, Style: MarshalExact}
+}
+
+// Quote returns a copy of d that is marshaled to a JSON string.
+func (d DecimalN) Quote() DecimalN {
+	d.Quoted = true
+	return d
+}
+
+// format returns the decimal written according to the format of d.
+func (d DecimalN) format() (string, error) {
+	switch d.Style {
+	case MarshalFixed:
+		rounded, inexact := d.Decimal.roundMode(d.Places, d.Rounding)
+		if inexact && d.Exact {
+			return "", ErrNumericInexact
+		}
+		return rounded.string(false), nil
+	case MarshalExact:
+		return d.Decimal.String(), nil
+	default:
+		return d.Decimal.StringFixed(stringPrecision), nil
+	}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (d DecimalN) MarshalJSON() ([]byte, error) {
+	str, err := d.format()
+	if err != nil {
+		return nil, err
+	}
+	if d.Quoted {
+		return []byte(`"` + str + `"`), nil
+	}
+	return []byte(str), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Both numbers and
+// strings are accepted; the format of d is kept.
+func (d *DecimalN) UnmarshalJSON(decimalBytes []byte) error {
+	return d.Decimal.UnmarshalJSON(decimalBytes)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for XML
+// serialization. Quoted is ignored.
+func (d DecimalN) MarshalText() (text []byte, err error) {
+	str, err := d.format()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(str), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for XML
+// deserialization. The format of d is kept.
+func (d *DecimalN) UnmarshalText(text []byte) error {
+	return d.Decimal.UnmarshalText(text)
+}
+
+// roundingModes maps the rounding options of the decimal struct tag to
+// rounding modes.
+var roundingModes = map[string]RoundingMode{
+	"halfup":   RoundHalfUp,
+	"halfeven": RoundHalfEven,
+	"down":     RoundDown,
+	"up":       RoundUp,
+	"floor":    RoundFloor,
+	"ceiling":  RoundCeiling,
+}
+
+// SetFormats sets the format of every DecimalN field of the struct pointed
+// to by v from its `decimal` struct tag and leaves the values alone, so it
+// can be called before or after unmarshaling. Nested structs, pointers,
+// slices and arrays are walked too; fields without the tag are kept.
+//
+// The tag is a number of places or "exact" for all significant digits,
+// optionally followed by "quoted" for a JSON string, "strict" to fail with
+// ErrNumericInexact instead of rounding and a rounding mode: "halfup" (the
+// default), "halfeven", "down", "up", "floor" or "ceiling".
+//
+// Example:
+//
+//	type Quote struct {
+//	    Price decimal.DecimalN `json:"price" decimal:"2,halfeven"`
+//	    Rate  decimal.DecimalN `json:"rate" decimal:"exact,quoted"`
+//	}
+//
+//	var q Quote
+//	json.Unmarshal([]byte(`{"price":12.345,"rate":0.0123456789}`), &q)
+//	decimal.SetFormats(&q)
+//	json.Marshal(q) // output: {"price":12.34,"rate":"0.0123456789"}
+func SetFormats(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decimal: SetFormats needs a non-nil pointer to a struct, got %T", v)
+	}
+	return setFormats(rv.Elem())
+}
+
+var decimalNType = reflect.TypeOf(DecimalN{})
+
+// setFormats sets the formats of the DecimalN fields found in v.
+func setFormats(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			return setFormats(v.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := setFormats(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		if v.Type() == decimalNType {
+			return nil
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field, f := v.Type().Field(i), v.Field(i)
+			if !f.CanSet() {
+				continue
+			}
+			tag, ok := field.Tag.Lookup("decimal")
+			if !ok {
+				if err := setFormats(f); err != nil {
+					return err
+				}
+				continue
+			}
+			if field.Type != decimalNType {
+				return fmt.Errorf("decimal: tag on field %s of type %s, expected DecimalN", field.Name, field.Type)
+			}
+			d := f.Addr().Interface().(*DecimalN)
+			if err := d.setFormat(tag); err != nil {
+				return fmt.Errorf("decimal: field %s: %w", field.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// setFormat sets the format of d from a decimal struct tag.
+func (d *DecimalN) setFormat(tag string) error {
+	format := DecimalN{Decimal: d.Decimal}
+	options := strings.Split(tag, ",")
+	if options[0] == "exact" {
+		format.Style = MarshalExact
+	} else {
+		places, err := strconv.ParseInt(options[0], 10, 32)
+		if err != nil {
+			return fmt.Errorf("can't parse places %q in tag %q", options[0], tag)
+		}
+		format.Style, format.Places = MarshalFixed, int32(places)
+	}
+	for _, option := range options[1:] {
+		if mode, ok := roundingModes[option]; ok {
+			format.Rounding = mode
+			continue
+		}
+		switch option {
+		case "quoted":
+			format.Quoted = true
+		case "strict":
+			format.Exact = true
+		default:
+			return fmt.Errorf("unknown option %q in tag %q", option, tag)
+		}
+	}
+	*d = format
+	return nil
+}
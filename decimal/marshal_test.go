@@ -0,0 +1,170 @@
+package decimal
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"testing"
+)
+
+func TestDecimalNMarshalJSON(t *testing.T) {
+	for _, testCase := range []struct {
+		d        DecimalN
+		expected string
+	}{
+		{DecimalN{Decimal: N("1.005")}, "1.01"},
+		{NewDecimalN(N("12.345"), 2, RoundHalfEven), "12.34"},
+		{NewDecimalN(N("12.345"), 2, RoundHalfUp), "12.35"},
+		{NewDecimalN(N("1.2"), 6, RoundHalfUp), "1.200000"},
+		{NewDecimalN(N("1234.5"), -2, RoundHalfUp), "1200"},
+		{NewDecimalN(N("-0.5"), 0, RoundFloor), "-1"},
+		{NewDecimalExact(N("0.0123456789")), "0.0123456789"},
+		{NewDecimalExact(N("1.500")), "1.5"},
+		{NewDecimalExact(N("123456789012345678901234567890.123456789")), "123456789012345678901234567890.123456789"},
+		{NewDecimalExact(N("0.0123456789")).Quote(), `"0.0123456789"`},
+		{NewDecimalN(N("7"), 2, RoundHalfUp).Quote(), `"7.00"`},
+		{DecimalN{Quoted: true}, `"0.00"`},
+	} {
+		b, err := json.Marshal(testCase.d)
+		if err != nil {
+			t.Errorf("%s: %v", testCase.d, err)
+		} else if string(b) != testCase.expected {
+			t.Errorf("%s: expected %s, got %s", testCase.d, testCase.expected, b)
+		}
+	}
+	exact := NewDecimalN(N("1.005"), 2, RoundHalfUp)
+	exact.Exact = true
+	if _, err := json.Marshal(exact); !errors.Is(err, ErrNumericInexact) {
+		t.Errorf("expected ErrNumericInexact, got %v", err)
+	}
+	exact.Decimal = N("1.5")
+	if b, err := json.Marshal(exact); err != nil || string(b) != "1.50" {
+		t.Errorf("expected 1.50, got %s (%v)", b, err)
+	}
+}
+
+func TestDecimalNStruct(t *testing.T) {
+	type quote struct {
+		Price DecimalN `json:"price" xml:"price"`
+		Rate  DecimalN `json:"rate" xml:"rate"`
+	}
+	q := quote{
+		Price: NewDecimalN(N("12.345"), 2, RoundHalfEven),
+		Rate:  NewDecimalN(N("0.01234567"), 6, RoundHalfUp).Quote(),
+	}
+
+	b, err := json.Marshal(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"price":12.34,"rate":"0.012346"}`; string(b) != expected {
+		t.Errorf("expected %s, got %s", expected, b)
+	}
+	decoded := quote{
+		Price: NewDecimalN(Zero, 2, RoundHalfEven),
+		Rate:  NewDecimalN(Zero, 6, RoundHalfUp).Quote(),
+	}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Price.Equals(N("12.34")) || !decoded.Rate.Equals(N("0.012346")) {
+		t.Errorf("expected 12.34 and 0.012346, got %s and %s", decoded.Price, decoded.Rate)
+	}
+	if decoded.Rate.Places != 6 || !decoded.Rate.Quoted {
+		t.Errorf("expected the format to be kept, got %+v", decoded.Rate)
+	}
+
+	b, err = xml.Marshal(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `<quote><price>12.34</price><rate>0.012346</rate></quote>`; string(b) != expected {
+		t.Errorf("expected %s, got %s", expected, b)
+	}
+	if err := xml.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Rate.Equals(N("0.012346")) {
+		t.Errorf("expected 0.012346, got %s", decoded.Rate)
+	}
+}
+
+func TestSetFormats(t *testing.T) {
+	type line struct {
+		Amount DecimalN `json:"amount" xml:"amount" decimal:"4,strict"`
+	}
+	type quote struct {
+		Price DecimalN  `json:"price" xml:"price" decimal:"2,halfeven"`
+		Rate  DecimalN  `json:"rate" xml:"rate" decimal:"6,quoted"`
+		Ratio DecimalN  `json:"ratio" xml:"ratio" decimal:"exact,quoted"`
+		Plain DecimalN  `json:"plain" xml:"plain"`
+		Lines []line    `json:"lines" xml:"lines"`
+		Total *DecimalN `json:"-" xml:"-"`
+	}
+	const encoded = `{"price":12.34,"rate":"0.012346","ratio":"0.0123456789","plain":1.00,"lines":[{"amount":1.0000}]}`
+
+	var q quote
+	if err := json.Unmarshal([]byte(`{"price":12.345,"rate":0.0123456,"ratio":"0.0123456789","plain":1,"lines":[{"amount":"1"}]}`), &q); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetFormats(&q); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != encoded {
+		t.Errorf("expected %s, got %s", encoded, b)
+	}
+
+	// the formats can also be set before decoding
+	var decoded quote
+	decoded.Lines = make([]line, 1)
+	if err := SetFormats(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if b, err = json.Marshal(decoded); err != nil || string(b) != encoded {
+		t.Errorf("expected %s, got %s (%v)", encoded, b, err)
+	}
+
+	if b, err = xml.Marshal(q); err != nil {
+		t.Fatal(err)
+	}
+	decoded = quote{}
+	if err := xml.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetFormats(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if b2, err := xml.Marshal(decoded); err != nil || string(b2) != string(b) {
+		t.Errorf("expected %s, got %s (%v)", b, b2, err)
+	}
+
+	q.Lines[0].Amount.Decimal = N("1.00005")
+	if _, err := json.Marshal(q); !errors.Is(err, ErrNumericInexact) {
+		t.Errorf("expected ErrNumericInexact, got %v", err)
+	}
+
+	for _, v := range []interface{}{
+		q,
+		(*quote)(nil),
+		&struct {
+			D Decimal `decimal:"2"`
+		}{},
+		&struct {
+			D DecimalN `decimal:"two"`
+		}{},
+		&struct {
+			D DecimalN `decimal:"2,nearest"`
+		}{},
+	} {
+		if err := SetFormats(v); err == nil {
+			t.Errorf("%T: expected error", v)
+		}
+	}
+}
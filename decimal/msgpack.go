@@ -0,0 +1,109 @@
+package decimal
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// MarshalMsgpack encodes d as a MessagePack string with the full precision,
+// as MessagePack has no standard decimal type.
+// It is compatible with the msgpack.Marshaler interface.
+func (d Decimal) MarshalMsgpack() ([]byte, error) {
+	s := d.String()
+	var b []byte
+	switch n := len(s); {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		b = binary.BigEndian.AppendUint16(append(b, 0xda), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, 0xdb), uint32(n))
+	}
+	return append(b, s...), nil
+}
+
+// UnmarshalMsgpack decodes d from a MessagePack string, integer or float.
+// It is compatible with the msgpack.Unmarshaler interface.
+func (d *Decimal) UnmarshalMsgpack(data []byte) error {
+	str, rest, err := readMsgpackNumber(data)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return fmt.Errorf("Error decoding MessagePack decimal: unexpected trailing data")
+	}
+	dec, err := NewFromString(str)
+	if err != nil {
+		return fmt.Errorf("Error decoding MessagePack decimal '%s': %s", str, err)
+	}
+	*d = dec
+	return nil
+}
+
+// msgpackSizes maps MessagePack format bytes to the size of the value or string length that follows.
+var msgpackSizes = map[byte]int{
+	0xca: 4, 0xcb: 8, // float 32, 64
+	0xcc: 1, 0xcd: 2, 0xce: 4, 0xcf: 8, // uint 8 - 64
+	0xd0: 1, 0xd1: 2, 0xd2: 4, 0xd3: 8, // int 8 - 64
+	0xd9: 1, 0xda: 2, 0xdb: 4, // str 8 - 32
+}
+
+// readMsgpackNumber reads a MessagePack string, integer or float and returns it as a string.
+func readMsgpackNumber(data []byte) (string, []byte, error) {
+	if len(data) == 0 {
+		return "", nil, fmt.Errorf("Error decoding MessagePack: unexpected end of data")
+	}
+	c := data[0]
+	switch {
+	case c <= 0x7f: // positive fixint
+		return strconv.Itoa(int(c)), data[1:], nil
+	case c >= 0xe0: // negative fixint
+		return strconv.Itoa(int(int8(c))), data[1:], nil
+	case c >= 0xa0 && c <= 0xbf: // fixstr
+		return readMsgpackString(data[1:], uint64(c&0x1f))
+	}
+
+	size, ok := msgpackSizes[c]
+	if !ok {
+		return "", nil, fmt.Errorf("Error decoding MessagePack: unsupported format 0x%02x", c)
+	}
+	if len(data) < 1+size {
+		return "", nil, fmt.Errorf("Error decoding MessagePack: unexpected end of data")
+	}
+	var n uint64
+	for _, b := range data[1 : 1+size] {
+		n = n<<8 | uint64(b)
+	}
+	rest := data[1+size:]
+
+	switch {
+	case c == 0xca || c == 0xcb:
+		f, bitSize := math.Float64frombits(n), 64
+		if c == 0xca {
+			f, bitSize = float64(math.Float32frombits(uint32(n))), 32
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", nil, fmt.Errorf("Error decoding MessagePack: %v is not a finite number", f)
+		}
+		return strconv.FormatFloat(f, 'f', -1, bitSize), rest, nil
+	case c <= 0xcf:
+		return strconv.FormatUint(n, 10), rest, nil
+	case c <= 0xd3:
+		shift := 64 - 8*uint(size)
+		return strconv.FormatInt(int64(n<<shift)>>shift, 10), rest, nil
+	default:
+		return readMsgpackString(rest, n)
+	}
+}
+
+// readMsgpackString reads a string of n bytes.
+func readMsgpackString(data []byte, n uint64) (string, []byte, error) {
+	if n > uint64(len(data)) {
+		return "", nil, fmt.Errorf("Error decoding MessagePack: unexpected end of data")
+	}
+	return string(data[:n]), data[n:], nil
+}
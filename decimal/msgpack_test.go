@@ -0,0 +1,77 @@
+package decimal
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestMsgpack(t *testing.T) {
+	for _, testCase := range []struct {
+		d   Decimal
+		hex string
+	}{
+		{New(27315, -2), "a63237332e3135"},
+		{New(-5, 3), "a52d35303030"},
+		{Decimal{}, "a130"},
+	} {
+		b, err := testCase.d.MarshalMsgpack()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := hex.EncodeToString(b); s != testCase.hex {
+			t.Errorf("%s: expected %s, got %s", testCase.d, testCase.hex, s)
+		}
+		var d Decimal
+		if err := d.UnmarshalMsgpack(b); err != nil {
+			t.Errorf("error decoding %s: %v", testCase.hex, err)
+		} else if !d.Equals(testCase.d) {
+			t.Errorf("expected %s, got %s", testCase.d, d)
+		}
+	}
+
+	long := N("0." + strings.Repeat("1", 40))
+	b, err := long.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b[0] != 0xd9 || b[1] != 42 {
+		t.Errorf("expected str 8 header, got %x", b[:2])
+	}
+	var d Decimal
+	if err := d.UnmarshalMsgpack(b); err != nil || !d.Equals(long) {
+		t.Errorf("expected %s, got %s, %v", long, d, err)
+	}
+
+	for s, expected := range map[string]string{
+		"7f":                 "127",
+		"ff":                 "-1",
+		"cc80":               "128",
+		"cdffff":             "65535",
+		"cfffffffffffffffff": "18446744073709551615",
+		"d0ff":               "-1",
+		"d1ff00":             "-256",
+		"d3ffffffffffffffff": "-1",
+		"ca3fc00000":         "1.5",
+		"ca3dcccccd":         "0.1",
+		"cb3ff8000000000000": "1.5",
+		"d903312e35":         "1.5",
+		"da0003312e35":       "1.5",
+	} {
+		data, _ := hex.DecodeString(s)
+		var d Decimal
+		if err := d.UnmarshalMsgpack(data); err != nil {
+			t.Errorf("error decoding %s: %v", s, err)
+		} else if d.String() != expected {
+			t.Errorf("%s: expected %s, got %s", s, expected, d)
+		}
+	}
+
+	for _, s := range []string{"", "c0", "a3616263", "a5312e35", "cd01", "cb7ff8000000000000", "7f00", "c3"} {
+		data, _ := hex.DecodeString(s)
+		var d Decimal
+		if err := d.UnmarshalMsgpack(data); err == nil {
+			t.Errorf("%s: expected error, got %s", s, d)
+		}
+	}
+}
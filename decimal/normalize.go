@@ -0,0 +1,93 @@
+package decimal
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math/big"
+)
+
+// Normalize returns d with the trailing zeros of the coefficient removed, so
+// 1.5, 1.50 and 15e-1 all become 15 * 10^-1. Zero is normalized to 0 * 10^0.
+// Decimals with Cmp(d2) == 0 always normalize to the same value and exponent.
+func (d Decimal) Normalize() Decimal {
+	d.ensureInitialized()
+	if d.value.Sign() == 0 {
+		return Decimal{value: new(big.Int), exp: 0}
+	}
+	value, exp := new(big.Int).Set(d.value), d.exp
+	q, r := new(big.Int), new(big.Int)
+	for {
+		q.QuoRem(value, tenInt, r)
+		if r.Sign() != 0 {
+			break
+		}
+		value, q = q, value
+		exp++
+	}
+	return Decimal{value: value, exp: exp}
+}
+
+// Key is a comparable representation of the numeric value of a Decimal.
+// Decimals that compare equal have equal keys, so Key can be used as a map
+// key or for deduplication:
+//
+//	seen := map[decimal.Key]bool{}
+//	seen[decimal.N("1.50").Key()] = true
+//	seen[decimal.N("1.5").Key()] // true
+type Key struct {
+	coefficient string
+	exp         int32
+}
+
+// Key returns the comparable key of d.
+func (d Decimal) Key() Key {
+	n := d.Normalize()
+	return Key{coefficient: n.value.String(), exp: n.exp}
+}
+
+// Decimal returns the normalized decimal of the key.
+func (k Key) Decimal() Decimal {
+	value, _ := new(big.Int).SetString(k.coefficient, 10)
+	if value == nil {
+		value = new(big.Int)
+	}
+	return Decimal{value: value, exp: k.exp}
+}
+
+// String returns the decimal of the key as a string.
+func (k Key) String() string {
+	return k.Decimal().String()
+}
+
+// Hash returns a 64-bit FNV-1a hash of the numeric value of d. Decimals that
+// compare equal have equal hashes. The hash is stable across processes.
+func (d Decimal) Hash() uint64 {
+	n := d.Normalize()
+	h := fnv.New64a()
+	var buf [5]byte
+	binary.BigEndian.PutUint32(buf[:4], uint32(n.exp))
+	buf[4] = byte(n.value.Sign() + 1)
+	h.Write(buf[:])
+	h.Write(n.value.Bytes())
+	return h.Sum64()
+}
+
+// Coefficient returns a copy of the coefficient of d, so that
+// d = Coefficient * 10^Exponent.
+func (d Decimal) Coefficient() *big.Int {
+	d.ensureInitialized()
+	return new(big.Int).Set(d.value)
+}
+
+// NumDigits returns the number of digits of the coefficient of d, including
+// trailing zeros, so New(1500, -3) has 4 digits. Zero has 1 digit.
+func (d Decimal) NumDigits() int {
+	d.ensureInitialized()
+	return numDigits(d.value)
+}
+
+// Scale returns the number of digits after the decimal point of d, i.e.
+// -Exponent. It is negative for decimals with a positive exponent.
+func (d Decimal) Scale() int32 {
+	return -d.exp
+}
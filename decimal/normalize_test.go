@@ -0,0 +1,103 @@
+package decimal
+
+import (
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	for _, testCase := range []struct {
+		d     Decimal
+		value string
+		exp   int32
+	}{
+		{N("1.5"), "15", -1},
+		{N("1.50"), "15", -1},
+		{New(15, -1), "15", -1},
+		{New(1500, 0), "15", 2},
+		{New(-1200, -5), "-12", -3},
+		{New(0, 5), "0", 0},
+		{New(0, -5), "0", 0},
+		{Decimal{}, "0", 0},
+		{New(7, 0), "7", 0},
+	} {
+		n := testCase.d.Normalize()
+		if n.value.String() != testCase.value || n.exp != testCase.exp {
+			t.Errorf("%s: expected %s (exp %d), got %s (exp %d)", testCase.d, testCase.value, testCase.exp, n.value, n.exp)
+		}
+		if n.Cmp(testCase.d) != 0 {
+			t.Errorf("%s: normalized value %s isn't equal", testCase.d, n)
+		}
+	}
+
+	d := New(100, 0)
+	d.Normalize()
+	if d.value.Int64() != 100 {
+		t.Errorf("Normalize modified the receiver: %s", d.value)
+	}
+}
+
+func TestKeyAndHash(t *testing.T) {
+	groups := [][]Decimal{
+		{N("1.5"), N("1.50"), New(15, -1), New(150000, -5)},
+		{N("-1.5"), New(-15, -1)},
+		{Decimal{}, New(0, 3), New(0, -3), Zero},
+		{New(1, 2), New(100, 0), N("100.000")},
+	}
+	keys := map[Key]int{}
+	hashes := map[uint64]int{}
+	for i, group := range groups {
+		for _, d := range group {
+			if j, ok := keys[d.Key()]; ok && j != i {
+				t.Errorf("%s: key collides with group %d", d, j)
+			}
+			if j, ok := hashes[d.Hash()]; ok && j != i {
+				t.Errorf("%s: hash collides with group %d", d, j)
+			}
+			keys[d.Key()], hashes[d.Hash()] = i, i
+		}
+	}
+	if len(keys) != len(groups) || len(hashes) != len(groups) {
+		t.Errorf("expected %d keys and hashes, got %d and %d", len(groups), len(keys), len(hashes))
+	}
+
+	k := N("12.340").Key()
+	if d := k.Decimal(); d.String() != "12.34" || d.exp != -2 {
+		t.Errorf("expected 12.34 (exp -2), got %s (exp %d)", d, d.exp)
+	}
+	if k.String() != "12.34" {
+		t.Errorf("expected 12.34, got %s", k)
+	}
+	if d := (Key{}).Decimal(); d.Cmp(Zero) != 0 {
+		t.Errorf("expected 0, got %s", d)
+	}
+}
+
+func TestAccessors(t *testing.T) {
+	d := New(-1500, -3)
+	c := d.Coefficient()
+	if c.Int64() != -1500 {
+		t.Errorf("expected -1500, got %s", c)
+	}
+	c.SetInt64(1)
+	if d.value.Int64() != -1500 {
+		t.Errorf("Coefficient returned the internal value")
+	}
+	if n := d.NumDigits(); n != 4 {
+		t.Errorf("expected 4, got %d", n)
+	}
+	if s := d.Scale(); s != 3 {
+		t.Errorf("expected 3, got %d", s)
+	}
+	if s := New(1, 2).Scale(); s != -2 {
+		t.Errorf("expected -2, got %d", s)
+	}
+	if n := (Decimal{}).NumDigits(); n != 1 {
+		t.Errorf("expected 1, got %d", n)
+	}
+	if c := (Decimal{}).Coefficient(); c.Sign() != 0 {
+		t.Errorf("expected 0, got %s", c)
+	}
+	if n := N("12345678901234567890.5").NumDigits(); n != 21 {
+		t.Errorf("expected 21, got %d", n)
+	}
+}
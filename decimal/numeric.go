@@ -0,0 +1,229 @@
+package decimal
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Errors returned by Fit, FitExact and RoundExact.
+var (
+	// ErrNumericOverflow is returned when a decimal has more integer digits
+	// than a NUMERIC(p, s) column allows, i.e. more than p - s.
+	ErrNumericOverflow = errors.New("decimal: value overflows NUMERIC precision")
+
+	// ErrNumericInexact is returned by FitExact and RoundExact when a decimal
+	// has more significant fractional digits than the scale.
+	ErrNumericInexact = errors.New("decimal: value exceeds NUMERIC scale")
+)
+
+// RoundingMode specifies how a decimal is rounded to a number of places.
+// Use RoundExact, FitExact or NewNumericExact where dropping digits must be
+// an error rather than rounding.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to the nearest neighbour, ties away from zero.
+	// This is how Round and SQL NUMERIC columns round.
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds to the nearest neighbour, ties to the even one
+	// (banker's rounding).
+	RoundHalfEven
+	// RoundDown rounds towards zero (truncation).
+	RoundDown
+	// RoundUp rounds away from zero.
+	RoundUp
+	// RoundFloor rounds towards negative infinity.
+	RoundFloor
+	// RoundCeiling rounds towards positive infinity.
+	RoundCeiling
+)
+
+// NumericSpec mirrors the SQL NUMERIC(precision, scale) type: at most
+// Precision significant digits, Scale of them after the decimal point.
+type NumericSpec struct {
+	Precision int32
+	Scale     int32
+}
+
+// Validate checks that the precision is positive and the scale is within
+// [0, precision].
+func (s NumericSpec) Validate() error {
+	if s.Precision < 1 || s.Scale < 0 || s.Scale > s.Precision {
+		return fmt.Errorf("decimal: invalid %s", s)
+	}
+	return nil
+}
+
+// String returns the spec in SQL syntax, e.g. "NUMERIC(10,2)".
+func (s NumericSpec) String() string {
+	return fmt.Sprintf("NUMERIC(%d,%d)", s.Precision, s.Scale)
+}
+
+// Fit rounds d to spec.Scale decimal places using mode and returns the
+// result with exactly spec.Scale places, so New(123, -1) fitted to
+// NUMERIC(5,2) is 12.30. It returns ErrNumericOverflow if the rounded value
+// has more than spec.Precision digits.
+func (d Decimal) Fit(spec NumericSpec, mode RoundingMode) (Decimal, error) {
+	return d.fit(spec, mode, false)
+}
+
+// FitExact is like Fit, but returns ErrNumericInexact instead of rounding
+// if d has more significant fractional digits than spec.Scale.
+func (d Decimal) FitExact(spec NumericSpec) (Decimal, error) {
+	return d.fit(spec, RoundDown, true)
+}
+
+func (d Decimal) fit(spec NumericSpec, mode RoundingMode, exact bool) (Decimal, error) {
+	if err := spec.Validate(); err != nil {
+		return Decimal{}, err
+	}
+	ret, inexact := d.roundMode(spec.Scale, mode)
+	if inexact && exact {
+		return Decimal{}, ErrNumericInexact
+	}
+	if ret.value.Sign() != 0 && numDigits(ret.value) > int(spec.Precision) {
+		return Decimal{}, ErrNumericOverflow
+	}
+	return ret, nil
+}
+
+// RoundMode rounds d to places decimal places using mode. Like Round, a
+// negative places rounds to tens, hundreds and so on.
+func (d Decimal) RoundMode(places int32, mode RoundingMode) Decimal {
+	ret, _ := d.roundMode(places, mode)
+	return ret
+}
+
+// RoundExact returns d with exactly places decimal places, so New(15, -1)
+// is 1.50 with 2 places. It returns ErrNumericInexact instead of rounding
+// if d has more significant fractional digits.
+func (d Decimal) RoundExact(places int32) (Decimal, error) {
+	ret, inexact := d.roundMode(places, RoundDown)
+	if inexact {
+		return Decimal{}, ErrNumericInexact
+	}
+	return ret, nil
+}
+
+// roundMode returns d rounded to places decimal places with the exponent
+// -places and reports whether any non-zero digits were dropped.
+func (d Decimal) roundMode(places int32, mode RoundingMode) (Decimal, bool) {
+	d.ensureInitialized()
+	if d.exp >= -places {
+		return d.rescale(-places), false
+	}
+
+	unit := powTen(int(-int64(places) - int64(d.exp)))
+	q, r := new(big.Int).QuoRem(new(big.Int).Abs(d.value), unit, new(big.Int))
+	if r.Sign() == 0 {
+		return Decimal{value: q.Mul(q, big.NewInt(int64(d.value.Sign()))), exp: -places}, false
+	}
+
+	negative := d.value.Sign() < 0
+	if mode.up(q, new(big.Int).Lsh(r, 1).Cmp(unit), negative) {
+		q.Add(q, oneInt)
+	}
+	if negative {
+		q.Neg(q)
+	}
+	return Decimal{value: q, exp: -places}, true
+}
+
+// up reports whether the absolute quotient q with a non-zero remainder is
+// rounded up. half is the comparison of the remainder with half of the divisor.
+func (mode RoundingMode) up(q *big.Int, half int, negative bool) bool {
+	switch mode {
+	case RoundHalfUp:
+		return half >= 0
+	case RoundHalfEven:
+		return half > 0 || half == 0 && q.Bit(0) == 1
+	case RoundUp:
+		return true
+	case RoundFloor:
+		return negative
+	case RoundCeiling:
+		return !negative
+	default:
+		return false
+	}
+}
+
+// Numeric is a Decimal constrained by a NumericSpec. Its Value method fits
+// the decimal to the spec before it is written to the database, so a model
+// can't silently lose digits that the column can't hold.
+//
+// Example:
+//
+//	type Invoice struct {
+//	    Amount decimal.Numeric
+//	}
+//
+//	amount, err := decimal.NewNumeric(decimal.N("12.345"), decimal.NumericSpec{10, 2}, decimal.RoundHalfEven)
+//	amount.String() // output: "12.34"
+type Numeric struct {
+	Decimal
+	Spec NumericSpec
+	Mode RoundingMode
+	// Exact makes fitting fail with ErrNumericInexact instead of rounding
+	// with Mode.
+	Exact bool
+}
+
+// NewNumeric returns d fitted to spec with mode.
+func NewNumeric(d Decimal, spec NumericSpec, mode RoundingMode) (Numeric, error) {
+	n := Numeric{Decimal: d, Spec: spec, Mode: mode}
+	return n, n.fitSelf()
+}
+
+// NewNumericExact returns d fitted to spec without rounding. It returns
+// ErrNumericInexact if d has more significant fractional digits than
+// spec.Scale.
+func NewNumericExact(d Decimal, spec NumericSpec) (Numeric, error) {
+	n := Numeric{Decimal: d, Spec: spec, Exact: true}
+	return n, n.fitSelf()
+}
+
+// fitSelf fits the decimal of n to its spec.
+func (n *Numeric) fitSelf() error {
+	fitted, err := n.Decimal.fit(n.Spec, n.Mode, n.Exact)
+	if err != nil {
+		*n = Numeric{}
+		return err
+	}
+	n.Decimal = fitted
+	return nil
+}
+
+// Value implements the driver.Valuer interface for database serialization.
+// The decimal is fitted to the spec and written with exactly Scale places.
+// As in Scan, a zero Spec means an unconstrained NUMERIC and the decimal is
+// written as is.
+func (n Numeric) Value() (driver.Value, error) {
+	if n.Spec == (NumericSpec{}) {
+		return n.Decimal.Value()
+	}
+	fitted, err := n.Decimal.fit(n.Spec, n.Mode, n.Exact)
+	if err != nil {
+		return nil, fmt.Errorf("Error converting %s to %s: %w", n.Decimal, n.Spec, err)
+	}
+	return fitted.string(false), nil
+}
+
+// Scan implements the sql.Scanner interface for database deserialization.
+// The scanned decimal is fitted to the spec if one is set.
+func (n *Numeric) Scan(value interface{}) error {
+	if err := n.Decimal.Scan(value); err != nil {
+		return err
+	}
+	if n.Spec == (NumericSpec{}) {
+		return nil
+	}
+	fitted, err := n.Decimal.fit(n.Spec, n.Mode, n.Exact)
+	if err != nil {
+		return fmt.Errorf("Error converting %s to %s: %w", n.Decimal, n.Spec, err)
+	}
+	n.Decimal = fitted
+	return nil
+}
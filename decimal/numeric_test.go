@@ -0,0 +1,176 @@
+package decimal
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRoundMode(t *testing.T) {
+	modes := []RoundingMode{RoundHalfUp, RoundHalfEven, RoundDown, RoundUp, RoundFloor, RoundCeiling}
+	for _, testCase := range []struct {
+		d        string
+		expected [6]string
+	}{
+		{"5.5", [6]string{"6", "6", "5", "6", "5", "6"}},
+		{"2.5", [6]string{"3", "2", "2", "3", "2", "3"}},
+		{"1.6", [6]string{"2", "2", "1", "2", "1", "2"}},
+		{"1.1", [6]string{"1", "1", "1", "2", "1", "2"}},
+		{"1.0", [6]string{"1", "1", "1", "1", "1", "1"}},
+		{"-1.0", [6]string{"-1", "-1", "-1", "-1", "-1", "-1"}},
+		{"-1.1", [6]string{"-1", "-1", "-1", "-2", "-2", "-1"}},
+		{"-1.6", [6]string{"-2", "-2", "-1", "-2", "-2", "-1"}},
+		{"-2.5", [6]string{"-3", "-2", "-2", "-3", "-3", "-2"}},
+		{"-5.5", [6]string{"-6", "-6", "-5", "-6", "-6", "-5"}},
+	} {
+		for i, mode := range modes {
+			if got := N(testCase.d).RoundMode(0, mode); got.String() != testCase.expected[i] || got.exp != 0 {
+				t.Errorf("%s in mode %d: expected %s, got %s (exp %d)", testCase.d, mode, testCase.expected[i], got, got.exp)
+			}
+		}
+	}
+
+	if got := N("1234.5678").RoundMode(2, RoundHalfEven); got.String() != "1234.57" {
+		t.Errorf("expected 1234.57, got %s", got)
+	}
+	if got := N("1250").RoundMode(-2, RoundHalfEven); got.String() != "1200" {
+		t.Errorf("expected 1200, got %s", got)
+	}
+	if got := N("1.5").RoundMode(3, RoundDown); got.String() != "1.5" || got.exp != -3 {
+		t.Errorf("expected 1.500, got %s (exp %d)", got, got.exp)
+	}
+}
+
+func TestFit(t *testing.T) {
+	spec := NumericSpec{Precision: 5, Scale: 2}
+	for _, testCase := range []struct {
+		d        string
+		mode     RoundingMode
+		expected string
+		err      error
+	}{
+		{"12.3", RoundHalfUp, "12.30", nil},
+		{"12.345", RoundHalfUp, "12.35", nil},
+		{"12.345", RoundHalfEven, "12.34", nil},
+		{"-12.345", RoundFloor, "-12.35", nil},
+		{"999.99", RoundHalfUp, "999.99", nil},
+		{"999.995", RoundHalfUp, "", ErrNumericOverflow},
+		{"999.995", RoundDown, "999.99", nil},
+		{"-1000", RoundHalfUp, "", ErrNumericOverflow},
+		{"0.001", RoundHalfUp, "0.00", nil},
+	} {
+		got, err := N(testCase.d).Fit(spec, testCase.mode)
+		if err != testCase.err {
+			t.Errorf("%s in mode %d: expected %v, got %v", testCase.d, testCase.mode, testCase.err, err)
+			continue
+		}
+		if err == nil && (got.string(false) != testCase.expected || got.exp != -2) {
+			t.Errorf("%s in mode %d: expected %s, got %s (exp %d)", testCase.d, testCase.mode, testCase.expected, got.string(false), got.exp)
+		}
+	}
+
+	for _, spec := range []NumericSpec{{}, {Precision: 5, Scale: -1}, {Precision: 2, Scale: 3}} {
+		if _, err := N("1").Fit(spec, RoundHalfUp); err == nil {
+			t.Errorf("%s: expected error", spec)
+		}
+	}
+}
+
+func TestFitExact(t *testing.T) {
+	spec := NumericSpec{Precision: 5, Scale: 2}
+	for _, testCase := range []struct {
+		d        string
+		expected string
+		err      error
+	}{
+		{"12.3", "12.30", nil},
+		{"12.340", "12.34", nil},
+		{"12.345", "", ErrNumericInexact},
+		{"-0.001", "", ErrNumericInexact},
+		{"1000", "", ErrNumericOverflow},
+	} {
+		got, err := N(testCase.d).FitExact(spec)
+		if err != testCase.err {
+			t.Errorf("%s: expected %v, got %v", testCase.d, testCase.err, err)
+		} else if err == nil && (got.string(false) != testCase.expected || got.exp != -2) {
+			t.Errorf("%s: expected %s, got %s (exp %d)", testCase.d, testCase.expected, got.string(false), got.exp)
+		}
+	}
+}
+
+func TestRoundExact(t *testing.T) {
+	for _, testCase := range []struct {
+		d        string
+		places   int32
+		expected string
+		err      error
+	}{
+		{"1.5", 2, "1.50", nil},
+		{"1.500", 1, "1.5", nil},
+		{"1200", -2, "1200", nil},
+		{"1.005", 2, "", ErrNumericInexact},
+		{"1250", -2, "", ErrNumericInexact},
+	} {
+		got, err := N(testCase.d).RoundExact(testCase.places)
+		if err != testCase.err {
+			t.Errorf("%s to %d places: expected %v, got %v", testCase.d, testCase.places, testCase.err, err)
+		} else if err == nil && (got.string(false) != testCase.expected || got.exp != -testCase.places) {
+			t.Errorf("%s to %d places: expected %s, got %s (exp %d)", testCase.d, testCase.places, testCase.expected, got.string(false), got.exp)
+		}
+	}
+}
+
+func TestNumeric(t *testing.T) {
+	spec := NumericSpec{Precision: 10, Scale: 2}
+	n, err := NewNumeric(N("12.345"), spec, RoundHalfEven)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.String() != "12.34" {
+		t.Errorf("expected 12.34, got %s", n)
+	}
+	if v, err := n.Value(); err != nil || v != "12.34" {
+		t.Errorf("expected 12.34, got %v, %v", v, err)
+	}
+	if _, err := NewNumeric(N("123456789"), spec, RoundHalfUp); err != ErrNumericOverflow {
+		t.Errorf("expected ErrNumericOverflow, got %v", err)
+	}
+	if _, err := NewNumericExact(N("12.345"), spec); err != ErrNumericInexact {
+		t.Errorf("expected ErrNumericInexact, got %v", err)
+	}
+	if exact, err := NewNumericExact(N("12.3"), spec); err != nil || !exact.Exact || exact.string(false) != "12.30" {
+		t.Errorf("expected exact 12.30, got %s, %v", exact.string(false), err)
+	}
+
+	// Value enforces the spec for decimals assigned directly
+	n.Decimal = N("7.005")
+	if v, err := n.Value(); err != nil || v != "7.00" {
+		t.Errorf("expected 7.00, got %v, %v", v, err)
+	}
+	n.Decimal = N("1E+10")
+	if _, err := n.Value(); !errors.Is(err, ErrNumericOverflow) {
+		t.Errorf("expected ErrNumericOverflow, got %v", err)
+	}
+	n.Exact = true
+	n.Decimal = N("1.001")
+	if _, err := n.Value(); !errors.Is(err, ErrNumericInexact) {
+		t.Errorf("expected ErrNumericInexact, got %v", err)
+	}
+
+	n = Numeric{Spec: spec}
+	if err := n.Scan([]byte("3.14159")); err != nil || n.string(false) != "3.14" {
+		t.Errorf("expected 3.14, got %s, %v", n.string(false), err)
+	}
+	if err := n.Scan([]byte("123456789012")); !errors.Is(err, ErrNumericOverflow) {
+		t.Errorf("expected ErrNumericOverflow, got %v", err)
+	}
+	var unchecked Numeric
+	if err := unchecked.Scan([]byte("3.14159")); err != nil || unchecked.String() != "3.14159" {
+		t.Errorf("expected 3.14159, got %s, %v", unchecked, err)
+	}
+	if v, err := unchecked.Value(); err != nil || v != "3.14159" {
+		t.Errorf("expected 3.14159, got %v, %v", v, err)
+	}
+	if v, err := (Numeric{}).Value(); err != nil || v != "0" {
+		t.Errorf("expected 0, got %v, %v", v, err)
+	}
+}
@@ -0,0 +1,161 @@
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Errors returned by the Parquet and Arrow DECIMAL conversions.
+var (
+	// ErrParquetPrecision is returned when a decimal has more significant
+	// digits than the precision of the DECIMAL column allows.
+	ErrParquetPrecision = errors.New("decimal: value exceeds Parquet DECIMAL precision")
+
+	// ErrParquetScale is returned when a decimal has more fractional digits
+	// than the scale of the DECIMAL column and would have to be rounded.
+	ErrParquetScale = errors.New("decimal: value exceeds Parquet DECIMAL scale")
+)
+
+// Maximum precision of the physical types of a Parquet DECIMAL column.
+const (
+	parquetInt32MaxPrecision = 9
+	parquetInt64MaxPrecision = 18
+	arrowDecimal128Precision = 38
+)
+
+// ParquetByteLength returns the minimal length of a FIXED_LEN_BYTE_ARRAY that
+// holds any value of the precision, e.g. 5 for DECIMAL(10, 2) and 16 for
+// DECIMAL(38, 0).
+func ParquetByteLength(precision int32) int {
+	n := 1
+	for new(big.Int).Lsh(oneInt, uint(8*n-1)).Cmp(powTen(int(precision))) < 0 {
+		n++
+	}
+	return n
+}
+
+// ParquetFixed converts d to the big-endian two's complement unscaled value
+// of length ParquetByteLength(p.Precision), as stored in a FIXED_LEN_BYTE_ARRAY
+// column. The DECIMAL(precision, scale) of the column is given as a NumericSpec.
+func (d Decimal) ParquetFixed(p NumericSpec) ([]byte, error) {
+	value, err := d.parquetUnscaled(p)
+	if err != nil {
+		return nil, err
+	}
+	return appendTwosComplement(nil, value, ParquetByteLength(p.Precision)), nil
+}
+
+// ParquetInt32 converts d to the unscaled value stored in an INT32 column.
+// The precision must not exceed 9.
+func (d Decimal) ParquetInt32(p NumericSpec) (int32, error) {
+	if p.Precision > parquetInt32MaxPrecision {
+		return 0, fmt.Errorf("decimal: %s can't be stored as Parquet INT32", p)
+	}
+	value, err := d.parquetUnscaled(p)
+	if err != nil {
+		return 0, err
+	}
+	return int32(value.Int64()), nil
+}
+
+// ParquetInt64 converts d to the unscaled value stored in an INT64 column.
+// The precision must not exceed 18.
+func (d Decimal) ParquetInt64(p NumericSpec) (int64, error) {
+	if p.Precision > parquetInt64MaxPrecision {
+		return 0, fmt.Errorf("decimal: %s can't be stored as Parquet INT64", p)
+	}
+	value, err := d.parquetUnscaled(p)
+	if err != nil {
+		return 0, err
+	}
+	return value.Int64(), nil
+}
+
+// NewFromParquetFixed returns a new Decimal from the big-endian two's
+// complement unscaled value of a FIXED_LEN_BYTE_ARRAY or BYTE_ARRAY column.
+func NewFromParquetFixed(b []byte, p NumericSpec) (Decimal, error) {
+	if len(b) == 0 {
+		return Decimal{}, fmt.Errorf("Error decoding Parquet DECIMAL: empty byte array")
+	}
+	value := new(big.Int).SetBytes(b)
+	if b[0]&0x80 != 0 {
+		value.Sub(value, new(big.Int).Lsh(oneInt, uint(8*len(b))))
+	}
+	return newFromParquetUnscaled(value, p)
+}
+
+// NewFromParquetInt32 returns a new Decimal from the unscaled value of an
+// INT32 column.
+func NewFromParquetInt32(value int32, p NumericSpec) (Decimal, error) {
+	return newFromParquetUnscaled(big.NewInt(int64(value)), p)
+}
+
+// NewFromParquetInt64 returns a new Decimal from the unscaled value of an
+// INT64 column.
+func NewFromParquetInt64(value int64, p NumericSpec) (Decimal, error) {
+	return newFromParquetUnscaled(big.NewInt(value), p)
+}
+
+// ArrowDecimal128 converts d to the high and low 64 bits of the unscaled value
+// of an Arrow decimal128 array, as expected by the Arrow decimal128.New.
+// The precision must not exceed 38.
+func (d Decimal) ArrowDecimal128(p NumericSpec) (high int64, low uint64, err error) {
+	if p.Precision > arrowDecimal128Precision {
+		return 0, 0, fmt.Errorf("decimal: %s can't be stored as Arrow decimal128", p)
+	}
+	value, err := d.parquetUnscaled(p)
+	if err != nil {
+		return 0, 0, err
+	}
+	hi, lo := split128(new(big.Int).SetBytes(appendTwosComplement(nil, value, 16)))
+	return int64(hi), lo, nil
+}
+
+// NewFromArrowDecimal128 returns a new Decimal from the high and low 64 bits
+// of the unscaled value of an Arrow decimal128 array.
+func NewFromArrowDecimal128(high int64, low uint64, p NumericSpec) (Decimal, error) {
+	value := join128(uint64(high), low)
+	if high < 0 {
+		value.Sub(value, new(big.Int).Lsh(oneInt, 128))
+	}
+	return newFromParquetUnscaled(value, p)
+}
+
+// parquetUnscaled returns the value of d scaled by 10^p.Scale. Fractional
+// digits are never rounded: ErrParquetScale is returned instead.
+func (d Decimal) parquetUnscaled(p NumericSpec) (*big.Int, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	d.ensureInitialized()
+	scaled := d.rescale(-p.Scale)
+	if scaled.Cmp(d) != 0 {
+		return nil, ErrParquetScale
+	}
+	if scaled.value.Sign() != 0 && numDigits(scaled.value) > int(p.Precision) {
+		return nil, ErrParquetPrecision
+	}
+	return scaled.value, nil
+}
+
+// newFromParquetUnscaled returns value * 10^-p.Scale after checking that it
+// fits the precision.
+func newFromParquetUnscaled(value *big.Int, p NumericSpec) (Decimal, error) {
+	if err := p.Validate(); err != nil {
+		return Decimal{}, err
+	}
+	if value.Sign() != 0 && numDigits(value) > int(p.Precision) {
+		return Decimal{}, ErrParquetPrecision
+	}
+	return Decimal{value: value, exp: -p.Scale}, nil
+}
+
+// appendTwosComplement appends the big-endian two's complement of value
+// in n bytes to b. The value must fit.
+func appendTwosComplement(b []byte, value *big.Int, n int) []byte {
+	if value.Sign() < 0 {
+		value = new(big.Int).Add(value, new(big.Int).Lsh(oneInt, uint(8*n)))
+	}
+	return append(b, value.FillBytes(make([]byte, n))...)
+}
@@ -0,0 +1,143 @@
+package decimal
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestParquetByteLength(t *testing.T) {
+	for _, testCase := range []struct {
+		precision int32
+		length    int
+	}{
+		{1, 1}, {2, 1}, {3, 2}, {9, 4}, {10, 5}, {18, 8}, {19, 9}, {38, 16}, {39, 17},
+	} {
+		if n := ParquetByteLength(testCase.precision); n != testCase.length {
+			t.Errorf("precision %d: expected %d, got %d", testCase.precision, testCase.length, n)
+		}
+	}
+}
+
+func TestParquetFixed(t *testing.T) {
+	p := NumericSpec{Precision: 10, Scale: 2}
+	for _, testCase := range []struct {
+		d     string
+		bytes string
+	}{
+		{"0", "0000000000"},
+		{"1.5", "0000000096"},
+		{"-1.5", "ffffffff6a"},
+		{"-0.01", "ffffffffff"},
+		{"99999999.99", "02540be3ff"},
+		{"-99999999.99", "fdabf41c01"},
+	} {
+		b, err := N(testCase.d).ParquetFixed(p)
+		if err != nil {
+			t.Errorf("error converting %s: %v", testCase.d, err)
+			continue
+		}
+		if s := hex.EncodeToString(b); s != testCase.bytes {
+			t.Errorf("%s: expected %s, got %s", testCase.d, testCase.bytes, s)
+		}
+		d, err := NewFromParquetFixed(b, p)
+		if err != nil {
+			t.Errorf("error converting %s: %v", testCase.bytes, err)
+		} else if !d.Equals(N(testCase.d)) || d.exp != -2 {
+			t.Errorf("expected %s, got %s (exp %d)", testCase.d, d, d.exp)
+		}
+	}
+
+	// wider arrays are sign extended
+	if d, err := NewFromParquetFixed([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x6a}, p); err != nil || d.String() != "-1.5" {
+		t.Errorf("expected -1.5, got %s, %v", d, err)
+	}
+	if _, err := NewFromParquetFixed(nil, p); err == nil {
+		t.Error("expected error for empty array")
+	}
+	if _, err := NewFromParquetFixed([]byte{0x02, 0x54, 0x0b, 0xe4, 0x00}, p); err != ErrParquetPrecision {
+		t.Errorf("expected ErrParquetPrecision, got %v", err)
+	}
+}
+
+func TestParquetInt(t *testing.T) {
+	p := NumericSpec{Precision: 9, Scale: 3}
+	v32, err := N("-123456.789").ParquetInt32(p)
+	if err != nil || v32 != -123456789 {
+		t.Errorf("expected -123456789, got %d, %v", v32, err)
+	}
+	if d, err := NewFromParquetInt32(v32, p); err != nil || d.String() != "-123456.789" {
+		t.Errorf("expected -123456.789, got %s, %v", d, err)
+	}
+	// trailing zeros are added up to the scale
+	v64, err := N("12").ParquetInt64(NumericSpec{Precision: 18, Scale: 4})
+	if err != nil || v64 != 120000 {
+		t.Errorf("expected 120000, got %d, %v", v64, err)
+	}
+	if d, err := NewFromParquetInt64(v64, NumericSpec{Precision: 18, Scale: 4}); err != nil || !d.Equals(New(12, 0)) || d.exp != -4 {
+		t.Errorf("expected 12.0000, got %s (exp %d), %v", d, d.exp, err)
+	}
+
+	if _, err := N("1").ParquetInt32(NumericSpec{Precision: 10}); err == nil {
+		t.Error("expected error for INT32 with precision 10")
+	}
+	if _, err := N("1").ParquetInt64(NumericSpec{Precision: 19}); err == nil {
+		t.Error("expected error for INT64 with precision 19")
+	}
+	if _, err := NewFromParquetInt32(1000, NumericSpec{Precision: 3}); err != ErrParquetPrecision {
+		t.Errorf("expected ErrParquetPrecision, got %v", err)
+	}
+}
+
+func TestParquetValidation(t *testing.T) {
+	for _, testCase := range []struct {
+		d   string
+		p   NumericSpec
+		err error
+	}{
+		{"1.234", NumericSpec{Precision: 5, Scale: 2}, ErrParquetScale},
+		{"1234", NumericSpec{Precision: 5, Scale: 2}, ErrParquetPrecision},
+		{"-1234", NumericSpec{Precision: 5, Scale: 2}, ErrParquetPrecision},
+		{"1.230", NumericSpec{Precision: 5, Scale: 2}, nil},
+		{"1E+2", NumericSpec{Precision: 3, Scale: 0}, nil},
+	} {
+		if _, err := N(testCase.d).ParquetInt64(testCase.p); err != testCase.err {
+			t.Errorf("%s in %s: expected %v, got %v", testCase.d, testCase.p, testCase.err, err)
+		}
+	}
+	for _, p := range []NumericSpec{{Precision: 0}, {Precision: 5, Scale: -1}, {Precision: 5, Scale: 6}} {
+		if err := p.Validate(); err == nil {
+			t.Errorf("%s: expected error", p)
+		}
+		if _, err := N("0").ParquetFixed(p); err == nil {
+			t.Errorf("%s: expected error", p)
+		}
+		if _, err := NewFromParquetInt64(0, p); err == nil {
+			t.Errorf("%s: expected error", p)
+		}
+	}
+}
+
+func TestArrowDecimal128(t *testing.T) {
+	p := NumericSpec{Precision: 38, Scale: 2}
+	for _, testCase := range []struct {
+		d    string
+		high int64
+		low  uint64
+	}{
+		{"1.5", 0, 150},
+		{"-1.5", -1, 0xffffffffffffff6a},
+		{"184467440737095516.16", 1, 0},
+	} {
+		high, low, err := N(testCase.d).ArrowDecimal128(p)
+		if err != nil || high != testCase.high || low != testCase.low {
+			t.Errorf("%s: expected %d %016x, got %d %016x, %v", testCase.d, testCase.high, testCase.low, high, low, err)
+		}
+		d, err := NewFromArrowDecimal128(testCase.high, testCase.low, p)
+		if err != nil || !d.Equals(N(testCase.d)) {
+			t.Errorf("expected %s, got %s, %v", testCase.d, d, err)
+		}
+	}
+	if _, _, err := N("1").ArrowDecimal128(NumericSpec{Precision: 39}); err == nil {
+		t.Error("expected error for precision 39")
+	}
+}
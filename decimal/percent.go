@@ -0,0 +1,216 @@
+package decimal
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Percent is a rate expressed in percent: the percent 12.5% has the rate
+// 0.125. The zero value is 0%.
+type Percent struct {
+	value Decimal
+}
+
+// BasisPoints is a rate expressed in basis points, hundredths of a percent:
+// 25bp is 0.25% and has the rate 0.0025. The zero value is 0bp.
+type BasisPoints struct {
+	value Decimal
+}
+
+// NewPercent returns the percent d, so NewPercent(N("12.5")) is 12.5%.
+func NewPercent(d Decimal) Percent {
+	return Percent{value: d}
+}
+
+// NewPercentFromRate returns the percent of rate, so
+// NewPercentFromRate(N("0.125")) is 12.5%.
+func NewPercentFromRate(rate Decimal) Percent {
+	rate.ensureInitialized()
+	return Percent{value: Decimal{value: rate.value, exp: rate.exp + 2}}
+}
+
+// ParsePercent parses a percent such as "12.5%", "-3 %" or "7". The percent
+// sign is optional.
+func ParsePercent(s string) (Percent, error) {
+	d, err := parseRate(s, "%")
+	if err != nil {
+		return Percent{}, fmt.Errorf("can't parse %q as a percent: %s", s, err)
+	}
+	return Percent{value: d}, nil
+}
+
+// Decimal returns the number of percent, 12.5 for 12.5%.
+func (p Percent) Decimal() Decimal {
+	p.value.ensureInitialized()
+	return p.value
+}
+
+// Rate returns the rate of p, 0.125 for 12.5%.
+func (p Percent) Rate() Decimal {
+	p.value.ensureInitialized()
+	return Decimal{value: p.value.value, exp: p.value.exp - 2}
+}
+
+// BasisPoints returns p in basis points, 1250bp for 12.5%.
+func (p Percent) BasisPoints() BasisPoints {
+	p.value.ensureInitialized()
+	return BasisPoints{value: Decimal{value: p.value.value, exp: p.value.exp + 2}}
+}
+
+// String returns p with the percent sign, e.g. "12.5%".
+func (p Percent) String() string {
+	return p.Decimal().String() + "%"
+}
+
+// NewBasisPoints returns d basis points, so NewBasisPoints(New(25, 0)) is 25bp.
+func NewBasisPoints(d Decimal) BasisPoints {
+	return BasisPoints{value: d}
+}
+
+// ParseBasisPoints parses basis points such as "25bp", "25 bps" or "25".
+// The suffix is optional and case-insensitive.
+func ParseBasisPoints(s string) (BasisPoints, error) {
+	d, err := parseRate(s, "bps", "bp")
+	if err != nil {
+		return BasisPoints{}, fmt.Errorf("can't parse %q as basis points: %s", s, err)
+	}
+	return BasisPoints{value: d}, nil
+}
+
+// Decimal returns the number of basis points, 25 for 25bp.
+func (b BasisPoints) Decimal() Decimal {
+	b.value.ensureInitialized()
+	return b.value
+}
+
+// Rate returns the rate of b, 0.0025 for 25bp.
+func (b BasisPoints) Rate() Decimal {
+	b.value.ensureInitialized()
+	return Decimal{value: b.value.value, exp: b.value.exp - 4}
+}
+
+// Percent returns b in percent, 0.25% for 25bp.
+func (b BasisPoints) Percent() Percent {
+	b.value.ensureInitialized()
+	return Percent{value: Decimal{value: b.value.value, exp: b.value.exp - 2}}
+}
+
+// String returns b with the "bp" suffix, e.g. "25bp".
+func (b BasisPoints) String() string {
+	return b.Decimal().String() + "bp"
+}
+
+// parseRate parses a decimal optionally followed by one of the suffixes.
+func parseRate(s string, suffixes ...string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	lower := strings.ToLower(s)
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(lower, suffix) {
+			s = strings.TrimSpace(s[:len(s)-len(suffix)])
+			break
+		}
+	}
+	return NewFromString(s)
+}
+
+// ApplyPercent returns p of d rounded to places decimal places using mode.
+//
+// Example:
+//
+//	N("80.00").ApplyPercent(NewPercent(N("12.5")), 2, RoundHalfEven) // output: 10.00
+func (d Decimal) ApplyPercent(p Percent, places int32, mode RoundingMode) Decimal {
+	return d.Mul(p.Rate()).RoundMode(places, mode)
+}
+
+// AddPercent returns d increased by p, rounded to places decimal places
+// using mode.
+func (d Decimal) AddPercent(p Percent, places int32, mode RoundingMode) Decimal {
+	return d.Mul(New(1, 0).Add(p.Rate())).RoundMode(places, mode)
+}
+
+// SubPercent returns d decreased by p, rounded to places decimal places
+// using mode.
+func (d Decimal) SubPercent(p Percent, places int32, mode RoundingMode) Decimal {
+	return d.Mul(New(1, 0).Sub(p.Rate())).RoundMode(places, mode)
+}
+
+// PercentOf returns how many percent d is of whole, rounded to places
+// decimal places of a percent using mode. It returns ErrDivisionByZero if
+// whole is zero.
+//
+// Example:
+//
+//	New(1, 0).PercentOf(New(3, 0), 2, RoundHalfUp) // output: 33.33%
+func (d Decimal) PercentOf(whole Decimal, places int32, mode RoundingMode) (Percent, error) {
+	whole.ensureInitialized()
+	if whole.value.Sign() == 0 {
+		return Percent{}, ErrDivisionByZero
+	}
+	return Percent{value: divRound(d, whole, 2, places, mode)}, nil
+}
+
+// PercentChange returns the change from from to to in percent of the
+// absolute value of from, rounded to places decimal places of a percent
+// using mode: the change from 80 to 100 is 25%, from -100 to -50 is 50%.
+// It returns ErrDivisionByZero if from is zero.
+func PercentChange(from, to Decimal, places int32, mode RoundingMode) (Percent, error) {
+	from.ensureInitialized()
+	if from.value.Sign() == 0 {
+		return Percent{}, ErrDivisionByZero
+	}
+	return Percent{value: divRound(to.Sub(from), from.Abs(), 2, places, mode)}, nil
+}
+
+// ExtractPercentIncluded splits the gross amount d that includes p on top
+// of the net amount, as prices include VAT, into net and the included
+// amount. The included amount is rounded to places decimal places using
+// mode and net is d minus it, so net + amount always equals d. It returns
+// ErrDivisionByZero if p is -100%.
+//
+// Example:
+//
+//	N("120.00").ExtractPercentIncluded(NewPercent(New(20, 0)), 2, RoundHalfUp) // output: 100.00, 20.00
+func (d Decimal) ExtractPercentIncluded(p Percent, places int32, mode RoundingMode) (net, amount Decimal, err error) {
+	rate := p.Rate()
+	base := New(1, 0).Add(rate)
+	if base.value.Sign() == 0 {
+		return Decimal{}, Decimal{}, ErrDivisionByZero
+	}
+	amount = divRound(d.Mul(rate), base, 0, places, mode)
+	return d.Sub(amount), amount, nil
+}
+
+// ExtractPercentExcluded computes p on top of the net amount d, as VAT is
+// charged on a price without it, and returns the gross amount and the
+// charged amount. The charged amount is rounded to places decimal places
+// using mode and gross is d plus it, so gross - amount always equals d.
+func (d Decimal) ExtractPercentExcluded(p Percent, places int32, mode RoundingMode) (gross, amount Decimal) {
+	amount = d.ApplyPercent(p, places, mode)
+	return d.Add(amount), amount
+}
+
+// divRound returns a / b * 10^shift rounded to places decimal places using
+// mode. b must not be zero.
+func divRound(a, b Decimal, shift, places int32, mode RoundingMode) Decimal {
+	a.ensureInitialized()
+	b.ensureInitialized()
+
+	num := new(big.Int).Abs(a.value)
+	den := new(big.Int).Abs(b.value)
+	if k := int64(a.exp) - int64(b.exp) + int64(shift) + int64(places); k >= 0 {
+		num.Mul(num, powTen(int(k)))
+	} else {
+		den.Mul(den, powTen(int(-k)))
+	}
+
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	negative := a.value.Sign()*b.value.Sign() < 0
+	if r.Sign() != 0 && mode.up(q, new(big.Int).Lsh(r, 1).Cmp(den), negative) {
+		q.Add(q, oneInt)
+	}
+	if negative {
+		q.Neg(q)
+	}
+	return Decimal{value: q, exp: -places}
+}
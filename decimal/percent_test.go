@@ -0,0 +1,209 @@
+package decimal
+
+import (
+	"testing"
+)
+
+func TestParsePercent(t *testing.T) {
+	for _, testCase := range []struct {
+		s        string
+		expected string
+	}{
+		{"12.5%", "12.5%"},
+		{" -3 % ", "-3%"},
+		{"7", "7%"},
+		{"0.25%", "0.25%"},
+		{"", ""},
+		{"%", ""},
+		{"12.5bp", ""},
+	} {
+		p, err := ParsePercent(testCase.s)
+		if testCase.expected == "" {
+			if err == nil {
+				t.Errorf("%q: expected error, got %s", testCase.s, p)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("error parsing %q: %v", testCase.s, err)
+		} else if p.String() != testCase.expected {
+			t.Errorf("%q: expected %s, got %s", testCase.s, testCase.expected, p)
+		}
+	}
+}
+
+func TestParseBasisPoints(t *testing.T) {
+	for _, testCase := range []struct {
+		s        string
+		expected string
+	}{
+		{"25bp", "25bp"},
+		{"25 bps", "25bp"},
+		{"-7.5BP", "-7.5bp"},
+		{"100", "100bp"},
+		{"bp", ""},
+		{"25%", ""},
+	} {
+		b, err := ParseBasisPoints(testCase.s)
+		if testCase.expected == "" {
+			if err == nil {
+				t.Errorf("%q: expected error, got %s", testCase.s, b)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("error parsing %q: %v", testCase.s, err)
+		} else if b.String() != testCase.expected {
+			t.Errorf("%q: expected %s, got %s", testCase.s, testCase.expected, b)
+		}
+	}
+}
+
+func TestPercentConversions(t *testing.T) {
+	p := NewPercent(N("12.5"))
+	if r := p.Rate(); !r.Equals(N("0.125")) {
+		t.Errorf("expected 0.125, got %s", r)
+	}
+	if b := p.BasisPoints(); b.String() != "1250bp" {
+		t.Errorf("expected 1250bp, got %s", b)
+	}
+	if q := NewPercentFromRate(N("0.125")); !q.Decimal().Equals(p.Decimal()) {
+		t.Errorf("expected %s, got %s", p, q)
+	}
+	b := NewBasisPoints(New(25, 0))
+	if r := b.Rate(); !r.Equals(N("0.0025")) {
+		t.Errorf("expected 0.0025, got %s", r)
+	}
+	if q := b.Percent(); q.String() != "0.25%" {
+		t.Errorf("expected 0.25%%, got %s", q)
+	}
+	if s := (Percent{}).String(); s != "0%" {
+		t.Errorf("expected 0%%, got %s", s)
+	}
+	if r := (BasisPoints{}).Rate(); !r.Equals(Zero) {
+		t.Errorf("expected 0, got %s", r)
+	}
+}
+
+func TestApplyPercent(t *testing.T) {
+	for _, testCase := range []struct {
+		d      string
+		p      string
+		places int32
+		mode   RoundingMode
+		apply  string
+		add    string
+		sub    string
+	}{
+		{"80", "12.5", 2, RoundHalfUp, "10.00", "90.00", "70.00"},
+		{"10.01", "50", 2, RoundHalfUp, "5.01", "15.02", "5.01"},
+		{"10.01", "50", 2, RoundHalfEven, "5.00", "15.02", "5.00"},
+		{"10.01", "50", 2, RoundDown, "5.00", "15.01", "5.00"},
+		{"-10.01", "50", 2, RoundFloor, "-5.01", "-15.02", "-5.01"},
+		{"100", "-10", 0, RoundHalfUp, "-10", "90", "110"},
+		{"1234", "1", -1, RoundHalfUp, "10", "1250", "1220"},
+	} {
+		d := N(testCase.d)
+		p := NewPercent(N(testCase.p))
+		if s := d.ApplyPercent(p, testCase.places, testCase.mode); !s.Equals(N(testCase.apply)) || s.exp != -testCase.places {
+			t.Errorf("%s of %s: expected %s, got %s", p, testCase.d, testCase.apply, s)
+		}
+		if s := d.AddPercent(p, testCase.places, testCase.mode); !s.Equals(N(testCase.add)) || s.exp != -testCase.places {
+			t.Errorf("%s + %s: expected %s, got %s", testCase.d, p, testCase.add, s)
+		}
+		if s := d.SubPercent(p, testCase.places, testCase.mode); !s.Equals(N(testCase.sub)) || s.exp != -testCase.places {
+			t.Errorf("%s - %s: expected %s, got %s", testCase.d, p, testCase.sub, s)
+		}
+	}
+	if s := N("2000").ApplyPercent(NewBasisPoints(New(25, 0)).Percent(), 2, RoundHalfUp); !s.Equals(New(5, 0)) {
+		t.Errorf("expected 5.00, got %s", s)
+	}
+}
+
+func TestPercentOf(t *testing.T) {
+	for _, testCase := range []struct {
+		d, whole string
+		places   int32
+		mode     RoundingMode
+		expected string
+	}{
+		{"1", "3", 2, RoundHalfUp, "33.33%"},
+		{"2", "3", 2, RoundHalfUp, "66.67%"},
+		{"2", "3", 2, RoundDown, "66.66%"},
+		{"1", "8", 1, RoundHalfEven, "12.5%"},
+		{"1", "800", 1, RoundHalfEven, "0.1%"},
+		{"3", "800", 1, RoundHalfEven, "0.4%"},
+		{"-1", "3", 0, RoundCeiling, "-33%"},
+		{"1", "-3", 0, RoundFloor, "-34%"},
+		{"50", "0.5", 0, RoundHalfUp, "10000%"},
+	} {
+		p, err := N(testCase.d).PercentOf(N(testCase.whole), testCase.places, testCase.mode)
+		if err != nil {
+			t.Errorf("%s of %s: %v", testCase.d, testCase.whole, err)
+		} else if p.String() != testCase.expected {
+			t.Errorf("%s of %s: expected %s, got %s", testCase.d, testCase.whole, testCase.expected, p)
+		}
+	}
+	if _, err := New(1, 0).PercentOf(Zero, 2, RoundHalfUp); err != ErrDivisionByZero {
+		t.Errorf("expected ErrDivisionByZero, got %v", err)
+	}
+}
+
+func TestPercentChange(t *testing.T) {
+	for _, testCase := range []struct {
+		from, to string
+		expected string
+	}{
+		{"80", "100", "25"},
+		{"100", "80", "-20"},
+		{"-100", "-50", "50"},
+		{"3", "4", "33.33"},
+		{"5", "5", "0"},
+	} {
+		p, err := PercentChange(N(testCase.from), N(testCase.to), 2, RoundHalfUp)
+		if err != nil {
+			t.Errorf("%s -> %s: %v", testCase.from, testCase.to, err)
+		} else if !p.Decimal().Equals(N(testCase.expected)) {
+			t.Errorf("%s -> %s: expected %s, got %s", testCase.from, testCase.to, testCase.expected, p)
+		}
+	}
+	if _, err := PercentChange(Zero, New(1, 0), 2, RoundHalfUp); err != ErrDivisionByZero {
+		t.Errorf("expected ErrDivisionByZero, got %v", err)
+	}
+}
+
+func TestExtractPercent(t *testing.T) {
+	vat := NewPercent(New(20, 0))
+	for _, testCase := range []struct {
+		d           string
+		mode        RoundingMode
+		net, amount string
+	}{
+		{"120.00", RoundHalfUp, "100", "20"},
+		{"100.00", RoundHalfUp, "83.33", "16.67"},
+		{"100.00", RoundDown, "83.34", "16.66"},
+		{"0.05", RoundHalfEven, "0.04", "0.01"},
+		{"-100", RoundHalfUp, "-83.33", "-16.67"},
+	} {
+		d := N(testCase.d)
+		net, amount, err := d.ExtractPercentIncluded(vat, 2, testCase.mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !net.Equals(N(testCase.net)) || !amount.Equals(N(testCase.amount)) {
+			t.Errorf("%s: expected %s + %s, got %s + %s", testCase.d, testCase.net, testCase.amount, net, amount)
+		}
+		if !net.Add(amount).Equals(d) {
+			t.Errorf("%s: %s + %s doesn't add up", testCase.d, net, amount)
+		}
+	}
+
+	if _, _, err := N("100").ExtractPercentIncluded(NewPercent(New(-100, 0)), 2, RoundHalfUp); err != ErrDivisionByZero {
+		t.Errorf("expected %v, got %v", ErrDivisionByZero, err)
+	}
+
+	gross, amount := N("83.33").ExtractPercentExcluded(vat, 2, RoundHalfUp)
+	if !gross.Equals(N("100")) || !amount.Equals(N("16.67")) {
+		t.Errorf("expected 100.00 and 16.67, got %s and %s", gross, amount)
+	}
+}
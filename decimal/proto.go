@@ -0,0 +1,142 @@
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/mihteh/types/internal/protowire"
+)
+
+// ProtoDecimal mirrors the google.type.Decimal message. Its fields match the
+// generated Go type and Marshal/Unmarshal use the protobuf wire format, so
+// no protobuf dependency is required.
+type ProtoDecimal struct {
+	Value string
+}
+
+// ProtoMoney mirrors the google.type.Money message: the whole units and the
+// nano (10^-9) units of the amount. Units and Nanos must have the same sign.
+type ProtoMoney struct {
+	CurrencyCode string
+	Units        int64
+	Nanos        int32
+}
+
+var nanosInt = big.NewInt(1e9)
+
+// ProtoDecimal converts d to google.type.Decimal keeping its scale,
+// so New(150, -2) becomes "1.50".
+func (d Decimal) ProtoDecimal() ProtoDecimal {
+	d.ensureInitialized()
+	return ProtoDecimal{Value: d.string(false)}
+}
+
+// ProtoDecimalToDecimal parses the google.type.Decimal value, which may have
+// a leading sign, a decimal point and an exponent, e.g. "+1.5e-3".
+func ProtoDecimalToDecimal(pd ProtoDecimal) (Decimal, error) {
+	value := pd.Value
+	if value == "" {
+		return Decimal{}, fmt.Errorf("can't convert empty google.type.Decimal to decimal")
+	}
+	if strings.HasPrefix(value, "+") {
+		value = value[1:]
+		if strings.HasPrefix(value, "-") || strings.HasPrefix(value, "+") {
+			return Decimal{}, fmt.Errorf("can't convert %s to decimal", pd.Value)
+		}
+	}
+	return NewFromString(value)
+}
+
+// ProtoMoney converts d to google.type.Money with the given currency code.
+// It returns an error if d has more than 9 significant fractional digits
+// or its integer part doesn't fit into int64.
+func (d Decimal) ProtoMoney(currencyCode string) (ProtoMoney, error) {
+	nanos := d.Mul(New(1, 9))
+	if nanos.exp < 0 && nanos.rescale(0).Cmp(nanos) != 0 {
+		return ProtoMoney{}, fmt.Errorf("can't convert %s to google.type.Money: more than 9 fractional digits", d)
+	}
+	units, frac := new(big.Int).QuoRem(nanos.rescale(0).value, nanosInt, new(big.Int))
+	if !units.IsInt64() {
+		return ProtoMoney{}, fmt.Errorf("can't convert %s to google.type.Money: units overflow int64", d)
+	}
+	return ProtoMoney{CurrencyCode: currencyCode, Units: units.Int64(), Nanos: int32(frac.Int64())}, nil
+}
+
+// Validate checks that Nanos is within (-10^9, 10^9) and has the same sign as Units.
+func (m ProtoMoney) Validate() error {
+	if m.Nanos <= -1e9 || m.Nanos >= 1e9 {
+		return fmt.Errorf("invalid google.type.Money: nanos %d out of range", m.Nanos)
+	}
+	if m.Units > 0 && m.Nanos < 0 || m.Units < 0 && m.Nanos > 0 {
+		return fmt.Errorf("invalid google.type.Money: units %d and nanos %d have different signs", m.Units, m.Nanos)
+	}
+	return nil
+}
+
+// ProtoMoneyToDecimal converts google.type.Money to a Decimal with 9 decimal
+// places and returns it with the currency code.
+func ProtoMoneyToDecimal(m ProtoMoney) (Decimal, string, error) {
+	if err := m.Validate(); err != nil {
+		return Decimal{}, "", err
+	}
+	value := new(big.Int).Mul(big.NewInt(m.Units), nanosInt)
+	value.Add(value, big.NewInt(int64(m.Nanos)))
+	return Decimal{value: value, exp: -9}, m.CurrencyCode, nil
+}
+
+// Marshal encodes pd in the protobuf wire format.
+func (pd ProtoDecimal) Marshal() ([]byte, error) {
+	return protowire.AppendString(nil, 1, pd.Value), nil
+}
+
+// Unmarshal decodes pd from the protobuf wire format.
+func (pd *ProtoDecimal) Unmarshal(data []byte) error {
+	*pd = ProtoDecimal{}
+	return readProto(data, func(field int, _ uint64, b []byte) {
+		if field == 1 {
+			pd.Value = string(b)
+		}
+	})
+}
+
+// Marshal encodes m in the protobuf wire format.
+func (m ProtoMoney) Marshal() ([]byte, error) {
+	b := protowire.AppendString(nil, 1, m.CurrencyCode)
+	b = protowire.AppendVarint(b, 2, uint64(m.Units))
+	b = protowire.AppendVarint(b, 3, uint64(int64(m.Nanos)))
+	return b, nil
+}
+
+// Unmarshal decodes m from the protobuf wire format.
+func (m *ProtoMoney) Unmarshal(data []byte) error {
+	*m = ProtoMoney{}
+	return readProto(data, func(field int, v uint64, b []byte) {
+		switch field {
+		case 1:
+			m.CurrencyCode = string(b)
+		case 2:
+			m.Units = int64(v)
+		case 3:
+			m.Nanos = int32(v)
+		}
+	})
+}
+
+// readProto reads the message with protowire.Read and describes its errors.
+func readProto(data []byte, set func(field int, v uint64, b []byte)) error {
+	err := protowire.Read(data, set)
+	var e *protowire.Error
+	if !errors.As(err, &e) {
+		return err
+	}
+	switch {
+	case e.Field == 0:
+		return fmt.Errorf("invalid protobuf message: bad field key")
+	case e.Unsupported:
+		return fmt.Errorf("invalid protobuf message: unsupported wire type %d of field %d", e.WireType, e.Field)
+	default:
+		return fmt.Errorf("invalid protobuf message: bad field %d", e.Field)
+	}
+}
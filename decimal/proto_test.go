@@ -0,0 +1,117 @@
+package decimal
+
+import (
+	"math"
+	"testing"
+)
+
+func TestProtoDecimal(t *testing.T) {
+	for _, testCase := range []struct {
+		d     Decimal
+		value string
+	}{
+		{New(150, -2), "1.50"},
+		{New(-12345, -3), "-12.345"},
+		{New(5, 3), "5000"},
+		{Decimal{}, "0"},
+		{New(1, -20), "0.00000000000000000001"},
+	} {
+		pd := testCase.d.ProtoDecimal()
+		if pd.Value != testCase.value {
+			t.Errorf("expected %s, got %s", testCase.value, pd.Value)
+		}
+		data, err := pd.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var pd2 ProtoDecimal
+		if err := pd2.Unmarshal(data); err != nil {
+			t.Fatal(err)
+		}
+		d, err := ProtoDecimalToDecimal(pd2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !d.Equals(testCase.d) {
+			t.Errorf("expected %s, got %s", testCase.d, d)
+		}
+	}
+
+	for value, expected := range map[string]string{"+1.5": "1.5", "-.5": "-0.5", "5.": "5", "1.5e-3": "0.0015", "2E2": "200"} {
+		d, err := ProtoDecimalToDecimal(ProtoDecimal{Value: value})
+		if err != nil {
+			t.Errorf("error converting %s: %v", value, err)
+		} else if d.String() != expected {
+			t.Errorf("expected %s, got %s", expected, d)
+		}
+	}
+	for _, value := range []string{"", "+-1", "++1", "1.2.3", "abc", "1e"} {
+		if d, err := ProtoDecimalToDecimal(ProtoDecimal{Value: value}); err == nil {
+			t.Errorf("expected error for %q, got %s", value, d)
+		}
+	}
+}
+
+func TestProtoMoney(t *testing.T) {
+	for _, testCase := range []struct {
+		d     Decimal
+		units int64
+		nanos int32
+	}{
+		{N("1234.5"), 1234, 500000000},
+		{N("-1.75"), -1, -750000000},
+		{N("-0.000000001"), 0, -1},
+		{N("9223372036854775807.999999999"), math.MaxInt64, 999999999},
+		{Decimal{}, 0, 0},
+	} {
+		m, err := testCase.d.ProtoMoney("RUB")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.CurrencyCode != "RUB" || m.Units != testCase.units || m.Nanos != testCase.nanos {
+			t.Errorf("%s: expected %d/%d, got %+v", testCase.d, testCase.units, testCase.nanos, m)
+		}
+		data, err := m.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var m2 ProtoMoney
+		if err := m2.Unmarshal(data); err != nil {
+			t.Fatal(err)
+		}
+		if m2 != m {
+			t.Errorf("expected %+v, got %+v", m, m2)
+		}
+		d, currency, err := ProtoMoneyToDecimal(m2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !d.Equals(testCase.d) || currency != "RUB" {
+			t.Errorf("expected %s RUB, got %s %s", testCase.d, d, currency)
+		}
+	}
+
+	for _, d := range []Decimal{N("0.0000000001"), N("9223372036854775808"), N("-9223372036854775809")} {
+		if m, err := d.ProtoMoney("USD"); err == nil {
+			t.Errorf("expected error for %s, got %+v", d, m)
+		}
+	}
+	for _, m := range []ProtoMoney{{Units: 1, Nanos: -1}, {Units: -1, Nanos: 1}, {Nanos: 1e9}, {Nanos: -1e9}} {
+		if d, _, err := ProtoMoneyToDecimal(m); err == nil {
+			t.Errorf("expected error for %+v, got %s", m, d)
+		}
+	}
+}
+
+func TestProtoUnmarshalErrors(t *testing.T) {
+	// a fixed32 field and an unknown string field must be skipped
+	var m ProtoMoney
+	if err := m.Unmarshal([]byte{0x25, 1, 2, 3, 4, 0x2a, 1, 'x', 0x10, 5}); err != nil || m.Units != 5 {
+		t.Errorf("expected units 5, got %+v, %v", m, err)
+	}
+	for _, data := range [][]byte{{0x0a, 5, 'R'}, {0x10}, {0x25, 1}, {0x0b}, {0x80}, {0x00, 0x01}} {
+		if err := m.Unmarshal(data); err == nil {
+			t.Errorf("expected error for %v", data)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Errors returned by the error-returning variants of the constructors and
+// operations that panic. They are wrapped, so use errors.Is to check them.
+var (
+	// ErrDivisionByZero is returned when dividing by zero.
+	ErrDivisionByZero = errors.New("decimal: division by zero")
+
+	// ErrExponentOverflow is returned when the exponent of a result doesn't
+	// fit into an int32.
+	ErrExponentOverflow = errors.New("decimal: exponent overflows int32")
+
+	// ErrNotFinite is returned when converting NaN or an infinity.
+	ErrNotFinite = errors.New("decimal: value is not finite")
+)
+
+// NewFromFloatE is like NewFromFloat, but returns ErrNotFinite for NaN and
+// infinities instead of panicking.
+func NewFromFloatE(value float64) (Decimal, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Decimal{}, fmt.Errorf("can't convert %v to decimal: %w", value, ErrNotFinite)
+	}
+	return NewFromFloat(value), nil
+}
+
+// NewFromFloatWithExponentE is like NewFromFloatWithExponent, but returns
+// ErrNotFinite for NaN and infinities instead of panicking.
+func NewFromFloatWithExponentE(value float64, exp int32) (Decimal, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Decimal{}, fmt.Errorf("can't convert %v to decimal: %w", value, ErrNotFinite)
+	}
+	return NewFromFloatWithExponent(value, exp), nil
+}
+
+// MulE is like Mul, but returns ErrExponentOverflow instead of panicking
+// when the exponent of the product doesn't fit into an int32.
+func (d Decimal) MulE(d2 Decimal) (Decimal, error) {
+	if exp := int64(d.exp) + int64(d2.exp); exp > math.MaxInt32 || exp < math.MinInt32 {
+		return Decimal{}, fmt.Errorf("can't multiply decimals: exponent %d: %w", exp, ErrExponentOverflow)
+	}
+	return d.Mul(d2), nil
+}
+
+// DivE is like Div, but returns ErrDivisionByZero instead of panicking when
+// d2 is zero.
+func (d Decimal) DivE(d2 Decimal) (Decimal, error) {
+	if d2.value == nil || d2.value.Sign() == 0 {
+		return Decimal{}, fmt.Errorf("can't divide %s by zero: %w", d, ErrDivisionByZero)
+	}
+	return d.Div(d2), nil
+}
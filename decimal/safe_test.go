@@ -0,0 +1,52 @@
+package decimal
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNewFromFloatE(t *testing.T) {
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if d, err := NewFromFloatE(f); !errors.Is(err, ErrNotFinite) {
+			t.Errorf("%v: expected ErrNotFinite, got %s, %v", f, d, err)
+		}
+		if d, err := NewFromFloatWithExponentE(f, -2); !errors.Is(err, ErrNotFinite) {
+			t.Errorf("%v: expected ErrNotFinite, got %s, %v", f, d, err)
+		}
+	}
+	if d, err := NewFromFloatE(123.45); err != nil || d.String() != "123.45" {
+		t.Errorf("expected 123.45, got %s, %v", d, err)
+	}
+	if d, err := NewFromFloatWithExponentE(123.456, -2); err != nil || d.String() != "123.46" {
+		t.Errorf("expected 123.46, got %s, %v", d, err)
+	}
+	if d, err := NewFromFloatWithExponentE(1e300, -10); err != nil || d.exp != -10 || d.Cmp(NewFromFloatExact(1e300)) != 0 {
+		t.Errorf("expected 1e300, got %s, %v", d, err)
+	}
+}
+
+func TestMulE(t *testing.T) {
+	if d, err := New(2, -3).MulE(New(3, 1)); err != nil || d.String() != "0.06" {
+		t.Errorf("expected 0.06, got %s, %v", d, err)
+	}
+	for _, testCase := range [][2]Decimal{
+		{New(1, math.MaxInt32), New(1, 1)},
+		{New(1, math.MinInt32), New(1, -1)},
+	} {
+		if d, err := testCase[0].MulE(testCase[1]); !errors.Is(err, ErrExponentOverflow) {
+			t.Errorf("expected ErrExponentOverflow, got exponent %d, %v", d.exp, err)
+		}
+	}
+}
+
+func TestDivE(t *testing.T) {
+	if d, err := New(1, 0).DivE(New(4, 0)); err != nil || d.String() != "0.25" {
+		t.Errorf("expected 0.25, got %s, %v", d, err)
+	}
+	for _, zero := range []Decimal{{}, New(0, 0), New(0, -5), Zero} {
+		if d, err := New(1, 0).DivE(zero); !errors.Is(err, ErrDivisionByZero) {
+			t.Errorf("expected ErrDivisionByZero, got %s, %v", d, err)
+		}
+	}
+}
@@ -0,0 +1,344 @@
+package decimal
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Gender is the grammatical gender of a Russian noun. Numerals one and two
+// agree with it: "один рубль", "одна гривна", "два рубля", "две гривны".
+type Gender int
+
+// Grammatical genders
+const (
+	Masculine Gender = iota
+	Feminine
+	Neuter
+)
+
+// CurrencyNames holds the names of a currency unit and its subunit in one
+// language. Forms are indexed by the plural category of the number: for
+// Russian "рубль" (1, 21), "рубля" (2-4, 22) and "рублей" (5-20, 25); for
+// English the singular followed by the plural twice.
+type CurrencyNames struct {
+	Unit       [3]string
+	UnitGender Gender
+	Subunit    [3]string
+}
+
+// Currency describes a currency for spelling amounts in words: the number of
+// digits of the subunit and the names in Russian and English.
+type Currency struct {
+	Code   string
+	Places int32
+	RU     CurrencyNames
+	EN     CurrencyNames
+}
+
+// Predefined currencies
+var (
+	CurrencyRUB = Currency{
+		Code:   "RUB",
+		Places: 2,
+		RU:     CurrencyNames{Unit: [3]string{"рубль", "рубля", "рублей"}, UnitGender: Masculine, Subunit: [3]string{"копейка", "копейки", "копеек"}},
+		EN:     CurrencyNames{Unit: [3]string{"ruble", "rubles", "rubles"}, Subunit: [3]string{"kopeck", "kopecks", "kopecks"}},
+	}
+	CurrencyUSD = Currency{
+		Code:   "USD",
+		Places: 2,
+		RU:     CurrencyNames{Unit: [3]string{"доллар США", "доллара США", "долларов США"}, UnitGender: Masculine, Subunit: [3]string{"цент", "цента", "центов"}},
+		EN:     CurrencyNames{Unit: [3]string{"dollar", "dollars", "dollars"}, Subunit: [3]string{"cent", "cents", "cents"}},
+	}
+	CurrencyEUR = Currency{
+		Code:   "EUR",
+		Places: 2,
+		RU:     CurrencyNames{Unit: [3]string{"евро", "евро", "евро"}, UnitGender: Masculine, Subunit: [3]string{"евроцент", "евроцента", "евроцентов"}},
+		EN:     CurrencyNames{Unit: [3]string{"euro", "euros", "euros"}, Subunit: [3]string{"cent", "cents", "cents"}},
+	}
+)
+
+// maxFractionWords is the maximum number of fractional digits spelled by
+// InWordsRU and InWordsEN; the value is rounded to it.
+const maxFractionWords = 14
+
+var (
+	onesRU = [3][10]string{
+		{"", "один", "два", "три", "четыре", "пять", "шесть", "семь", "восемь", "девять"},
+		{"", "одна", "две", "три", "четыре", "пять", "шесть", "семь", "восемь", "девять"},
+		{"", "одно", "два", "три", "четыре", "пять", "шесть", "семь", "восемь", "девять"},
+	}
+	teensRU    = [10]string{"десять", "одиннадцать", "двенадцать", "тринадцать", "четырнадцать", "пятнадцать", "шестнадцать", "семнадцать", "восемнадцать", "девятнадцать"}
+	tensRU     = [10]string{"", "", "двадцать", "тридцать", "сорок", "пятьдесят", "шестьдесят", "семьдесят", "восемьдесят", "девяносто"}
+	hundredsRU = [10]string{"", "сто", "двести", "триста", "четыреста", "пятьсот", "шестьсот", "семьсот", "восемьсот", "девятьсот"}
+	scalesRU   = []struct {
+		forms  [3]string
+		gender Gender
+	}{
+		{[3]string{"тысяча", "тысячи", "тысяч"}, Feminine},
+		{[3]string{"миллион", "миллиона", "миллионов"}, Masculine},
+		{[3]string{"миллиард", "миллиарда", "миллиардов"}, Masculine},
+		{[3]string{"триллион", "триллиона", "триллионов"}, Masculine},
+		{[3]string{"квадриллион", "квадриллиона", "квадриллионов"}, Masculine},
+		{[3]string{"квинтиллион", "квинтиллиона", "квинтиллионов"}, Masculine},
+		{[3]string{"секстиллион", "секстиллиона", "секстиллионов"}, Masculine},
+		{[3]string{"септиллион", "септиллиона", "септиллионов"}, Masculine},
+		{[3]string{"октиллион", "октиллиона", "октиллионов"}, Masculine},
+		{[3]string{"нониллион", "нониллиона", "нониллионов"}, Masculine},
+		{[3]string{"дециллион", "дециллиона", "дециллионов"}, Masculine},
+	}
+	// stems of the ordinal denominators 10^1 - 10^14: "десят-ая", "сот-ых"
+	fractionsRU = []string{"десят", "сот", "тысячн", "миллионн", "миллиардн", "триллионн"}
+
+	onesEN   = [20]string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	tensEN   = [10]string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	scalesEN = []string{"thousand", "million", "billion", "trillion", "quadrillion", "quintillion", "sextillion", "septillion", "octillion", "nonillion", "decillion"}
+)
+
+// InWordsRU returns d spelled out in Russian, in lower case. Fractions are
+// spelled with the ordinal denominator: 1.25 is "одна целая двадцать пять
+// сотых". Up to 14 fractional digits are spelled, d is rounded to them.
+// Integer parts of 10^36 and more are written with digits.
+func (d Decimal) InWordsRU() string {
+	negative, intPart, fracPart, places := d.wordsParts()
+	var words string
+	if places == 0 {
+		words = spellRU(intPart, Masculine)
+	} else {
+		words = spellRU(intPart, Feminine) + " " + [3]string{"целая", "целых", "целых"}[pluralRU(intPart)] +
+			" " + spellRU(fracPart, Feminine) + " " + fractionRU(places, pluralRU(fracPart))
+	}
+	if negative {
+		return "минус " + words
+	}
+	return words
+}
+
+// InWordsEN returns d spelled out in English, in lower case. Fractional digits
+// are read one by one: 1.25 is "one point two five". Up to 14 fractional
+// digits are spelled, d is rounded to them. Integer parts of 10^36 and more
+// are written with digits.
+func (d Decimal) InWordsEN() string {
+	negative, intPart, fracPart, places := d.wordsParts()
+	words := spellEN(intPart)
+	if places > 0 {
+		digits := fmt.Sprintf("%0*s", places, fracPart.String())
+		words += " point"
+		for _, c := range digits {
+			words += " " + onesEN[c-'0']
+		}
+	}
+	if negative {
+		return "minus " + words
+	}
+	return words
+}
+
+// AmountInWordsRU returns the amount d in c as required in Russian payment
+// documents: the units are spelled out in agreement with the currency name,
+// the subunits are written with digits, and the first letter is capitalized.
+// d is rounded half away from zero to c.Places.
+//
+// Example:
+//
+//	N("1200.5").AmountInWordsRU(CurrencyRUB) // output: "Одна тысяча двести рублей 50 копеек"
+func (d Decimal) AmountInWordsRU(c Currency) string {
+	negative, units, subunits := d.amountParts(c)
+	words := spellRU(units, c.RU.UnitGender) + " " + c.RU.Unit[pluralRU(units)]
+	if c.Places > 0 {
+		words += " " + fmt.Sprintf("%0*s", c.Places, subunits.String()) + " " + c.RU.Subunit[pluralRU(subunits)]
+	}
+	if negative {
+		words = "минус " + words
+	}
+	return capitalize(words)
+}
+
+// AmountInWordsEN returns the amount d in c with the units spelled out in
+// English and the subunits written with digits, e.g. "One thousand two
+// hundred dollars and 50 cents". d is rounded half away from zero to c.Places.
+func (d Decimal) AmountInWordsEN(c Currency) string {
+	negative, units, subunits := d.amountParts(c)
+	words := spellEN(units) + " " + c.EN.Unit[pluralEN(units)]
+	if c.Places > 0 {
+		words += " and " + fmt.Sprintf("%0*s", c.Places, subunits.String()) + " " + c.EN.Subunit[pluralEN(subunits)]
+	}
+	if negative {
+		words = "minus " + words
+	}
+	return capitalize(words)
+}
+
+// wordsParts returns the sign, the integer part and the fractional digits of
+// d as an integer with the number of places, without trailing zeros.
+func (d Decimal) wordsParts() (negative bool, intPart, fracPart *big.Int, places int32) {
+	n := d.Normalize()
+	if n.exp < -maxFractionWords {
+		n = d.Round(maxFractionWords).Normalize()
+	}
+	negative = n.value.Sign() < 0
+	if n.exp >= 0 {
+		return negative, new(big.Int).Abs(n.rescale(0).value), new(big.Int), 0
+	}
+	intPart, fracPart = new(big.Int).QuoRem(new(big.Int).Abs(n.value), powTen(int(-n.exp)), new(big.Int))
+	return negative, intPart, fracPart, -n.exp
+}
+
+// amountParts returns the sign, the units and the subunits of d rounded to
+// the places of c.
+func (d Decimal) amountParts(c Currency) (negative bool, units, subunits *big.Int) {
+	r := d.Round(c.Places)
+	negative = r.value.Sign() < 0
+	units, subunits = new(big.Int).QuoRem(new(big.Int).Abs(r.value), powTen(int(c.Places)), new(big.Int))
+	return negative, units, subunits
+}
+
+// triads splits the decimal digits of n >= 0 into groups of three from the
+// right, the least significant group first.
+func triads(n *big.Int) []int {
+	s := n.String()
+	var groups []int
+	for len(s) > 0 {
+		i := len(s) - 3
+		if i < 0 {
+			i = 0
+		}
+		g, _ := strconv.Atoi(s[i:])
+		groups = append(groups, g)
+		s = s[:i]
+	}
+	return groups
+}
+
+// spellRU spells n >= 0 in Russian, one and two agree with gender.
+func spellRU(n *big.Int, gender Gender) string {
+	if n.Sign() == 0 {
+		return "ноль"
+	}
+	groups := triads(n)
+	if len(groups) > len(scalesRU)+1 {
+		return n.String()
+	}
+	var words []string
+	for i := len(groups) - 1; i >= 0; i-- {
+		if groups[i] == 0 {
+			continue
+		}
+		g := gender
+		if i > 0 {
+			g = scalesRU[i-1].gender
+		}
+		words = append(words, triadRU(groups[i], g)...)
+		if i > 0 {
+			words = append(words, scalesRU[i-1].forms[pluralRU(big.NewInt(int64(groups[i])))])
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// triadRU spells 1 <= n <= 999 in Russian.
+func triadRU(n int, gender Gender) []string {
+	var words []string
+	if n >= 100 {
+		words = append(words, hundredsRU[n/100])
+	}
+	switch n %= 100; {
+	case n >= 20:
+		words = append(words, tensRU[n/10])
+		if n%10 != 0 {
+			words = append(words, onesRU[gender][n%10])
+		}
+	case n >= 10:
+		words = append(words, teensRU[n-10])
+	case n > 0:
+		words = append(words, onesRU[gender][n])
+	}
+	return words
+}
+
+// fractionRU returns the denominator 10^-places in the plural form.
+func fractionRU(places int32, form int) string {
+	stem := fractionsRU[0]
+	if places > 1 {
+		stem = fractionsRU[1]
+	}
+	if places >= 3 {
+		stem = [3]string{"", "десяти", "сто"}[places%3] + fractionsRU[places/3+1]
+	}
+	if form == 0 {
+		return stem + "ая"
+	}
+	return stem + "ых"
+}
+
+// pluralRU returns the Russian plural category of n >= 0:
+// 0 for 1, 21, 101; 1 for 2-4, 22-24; 2 for 0, 5-20, 25-30.
+func pluralRU(n *big.Int) int {
+	last := int(new(big.Int).Rem(n, big.NewInt(100)).Int64())
+	switch {
+	case last >= 11 && last <= 14:
+		return 2
+	case last%10 == 1:
+		return 0
+	case last%10 >= 2 && last%10 <= 4:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// spellEN spells n >= 0 in English without "and": "one hundred twenty-three".
+func spellEN(n *big.Int) string {
+	if n.Sign() == 0 {
+		return onesEN[0]
+	}
+	groups := triads(n)
+	if len(groups) > len(scalesEN)+1 {
+		return n.String()
+	}
+	var words []string
+	for i := len(groups) - 1; i >= 0; i-- {
+		if groups[i] == 0 {
+			continue
+		}
+		words = append(words, triadEN(groups[i])...)
+		if i > 0 {
+			words = append(words, scalesEN[i-1])
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// triadEN spells 1 <= n <= 999 in English.
+func triadEN(n int) []string {
+	var words []string
+	if n >= 100 {
+		words = append(words, onesEN[n/100], "hundred")
+	}
+	switch n %= 100; {
+	case n >= 20 && n%10 != 0:
+		words = append(words, tensEN[n/10]+"-"+onesEN[n%10])
+	case n >= 20:
+		words = append(words, tensEN[n/10])
+	case n > 0:
+		words = append(words, onesEN[n])
+	}
+	return words
+}
+
+// pluralEN returns 0 for 1 and 1 for other numbers.
+func pluralEN(n *big.Int) int {
+	if n.IsInt64() && n.Int64() == 1 {
+		return 0
+	}
+	return 1
+}
+
+// capitalize returns s with the first letter in upper case.
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,124 @@
+package decimal
+
+import (
+	"testing"
+)
+
+func TestAmountInWordsRU(t *testing.T) {
+	for _, testCase := range []struct {
+		d        string
+		c        Currency
+		expected string
+	}{
+		{"1200.50", CurrencyRUB, "Одна тысяча двести рублей 50 копеек"},
+		{"0", CurrencyRUB, "Ноль рублей 00 копеек"},
+		{"0.01", CurrencyRUB, "Ноль рублей 01 копейка"},
+		{"0.02", CurrencyRUB, "Ноль рублей 02 копейки"},
+		{"0.11", CurrencyRUB, "Ноль рублей 11 копеек"},
+		{"0.21", CurrencyRUB, "Ноль рублей 21 копейка"},
+		{"1", CurrencyRUB, "Один рубль 00 копеек"},
+		{"2", CurrencyRUB, "Два рубля 00 копеек"},
+		{"4", CurrencyRUB, "Четыре рубля 00 копеек"},
+		{"5", CurrencyRUB, "Пять рублей 00 копеек"},
+		{"10", CurrencyRUB, "Десять рублей 00 копеек"},
+		{"11", CurrencyRUB, "Одиннадцать рублей 00 копеек"},
+		{"12", CurrencyRUB, "Двенадцать рублей 00 копеек"},
+		{"14", CurrencyRUB, "Четырнадцать рублей 00 копеек"},
+		{"19", CurrencyRUB, "Девятнадцать рублей 00 копеек"},
+		{"20", CurrencyRUB, "Двадцать рублей 00 копеек"},
+		{"21", CurrencyRUB, "Двадцать один рубль 00 копеек"},
+		{"22", CurrencyRUB, "Двадцать два рубля 00 копеек"},
+		{"100", CurrencyRUB, "Сто рублей 00 копеек"},
+		{"101", CurrencyRUB, "Сто один рубль 00 копеек"},
+		{"111", CurrencyRUB, "Сто одиннадцать рублей 00 копеек"},
+		{"999", CurrencyRUB, "Девятьсот девяносто девять рублей 00 копеек"},
+		{"1000", CurrencyRUB, "Одна тысяча рублей 00 копеек"},
+		{"1001", CurrencyRUB, "Одна тысяча один рубль 00 копеек"},
+		{"2000", CurrencyRUB, "Две тысячи рублей 00 копеек"},
+		{"5000", CurrencyRUB, "Пять тысяч рублей 00 копеек"},
+		{"11000", CurrencyRUB, "Одиннадцать тысяч рублей 00 копеек"},
+		{"21000", CurrencyRUB, "Двадцать одна тысяча рублей 00 копеек"},
+		{"22000", CurrencyRUB, "Двадцать две тысячи рублей 00 копеек"},
+		{"100000", CurrencyRUB, "Сто тысяч рублей 00 копеек"},
+		{"999999.99", CurrencyRUB, "Девятьсот девяносто девять тысяч девятьсот девяносто девять рублей 99 копеек"},
+		{"1000000", CurrencyRUB, "Один миллион рублей 00 копеек"},
+		{"2000000", CurrencyRUB, "Два миллиона рублей 00 копеек"},
+		{"1002003", CurrencyRUB, "Один миллион две тысячи три рубля 00 копеек"},
+		{"5000000000", CurrencyRUB, "Пять миллиардов рублей 00 копеек"},
+		{"1000000000000", CurrencyRUB, "Один триллион рублей 00 копеек"},
+		{"-1.5", CurrencyRUB, "Минус один рубль 50 копеек"},
+		{"0.005", CurrencyRUB, "Ноль рублей 01 копейка"},
+		{"0.994", CurrencyRUB, "Ноль рублей 99 копеек"},
+		{"0.995", CurrencyRUB, "Один рубль 00 копеек"},
+		{"-0.001", CurrencyRUB, "Ноль рублей 00 копеек"},
+		{"21.21", CurrencyUSD, "Двадцать один доллар США 21 цент"},
+		{"3", CurrencyEUR, "Три евро 00 евроцентов"},
+		{"2", Currency{Places: 0, RU: CurrencyNames{Unit: [3]string{"гривна", "гривны", "гривен"}, UnitGender: Feminine}}, "Две гривны"},
+		{"1", Currency{Places: 0, RU: CurrencyNames{Unit: [3]string{"песо", "песо", "песо"}, UnitGender: Neuter}}, "Одно песо"},
+		{"1.5", Currency{Places: 3, RU: CurrencyNames{Unit: [3]string{"динар", "динара", "динаров"}, Subunit: [3]string{"филс", "филса", "филсов"}}}, "Один динар 500 филсов"},
+	} {
+		if s := N(testCase.d).AmountInWordsRU(testCase.c); s != testCase.expected {
+			t.Errorf("%s: expected %q, got %q", testCase.d, testCase.expected, s)
+		}
+	}
+}
+
+func TestAmountInWordsEN(t *testing.T) {
+	for _, testCase := range []struct {
+		d        string
+		c        Currency
+		expected string
+	}{
+		{"1200.50", CurrencyUSD, "One thousand two hundred dollars and 50 cents"},
+		{"0", CurrencyUSD, "Zero dollars and 00 cents"},
+		{"1.01", CurrencyUSD, "One dollar and 01 cent"},
+		{"2.02", CurrencyUSD, "Two dollars and 02 cents"},
+		{"13", CurrencyUSD, "Thirteen dollars and 00 cents"},
+		{"21", CurrencyUSD, "Twenty-one dollars and 00 cents"},
+		{"40", CurrencyUSD, "Forty dollars and 00 cents"},
+		{"101", CurrencyUSD, "One hundred one dollars and 00 cents"},
+		{"999", CurrencyUSD, "Nine hundred ninety-nine dollars and 00 cents"},
+		{"1000001", CurrencyUSD, "One million one dollars and 00 cents"},
+		{"1234567890", CurrencyUSD, "One billion two hundred thirty-four million five hundred sixty-seven thousand eight hundred ninety dollars and 00 cents"},
+		{"-7.5", CurrencyEUR, "Minus seven euros and 50 cents"},
+		{"1", CurrencyRUB, "One ruble and 00 kopecks"},
+	} {
+		if s := N(testCase.d).AmountInWordsEN(testCase.c); s != testCase.expected {
+			t.Errorf("%s: expected %q, got %q", testCase.d, testCase.expected, s)
+		}
+	}
+}
+
+func TestInWords(t *testing.T) {
+	for _, testCase := range []struct {
+		d      string
+		ru, en string
+	}{
+		{"0", "ноль", "zero"},
+		{"1", "один", "one"},
+		{"-15", "минус пятнадцать", "minus fifteen"},
+		{"1.25", "одна целая двадцать пять сотых", "one point two five"},
+		{"2.5", "две целых пять десятых", "two point five"},
+		{"0.1", "ноль целых одна десятая", "zero point one"},
+		{"0.05", "ноль целых пять сотых", "zero point zero five"},
+		{"21.001", "двадцать одна целая одна тысячная", "twenty-one point zero zero one"},
+		{"3.0002", "три целых две десятитысячных", "three point zero zero zero two"},
+		{"0.00011", "ноль целых одиннадцать стотысячных", "zero point zero zero zero one one"},
+		{"0.000001", "ноль целых одна миллионная", "zero point zero zero zero zero zero one"},
+		{"1.500", "одна целая пять десятых", "one point five"},
+		{"1E+6", "один миллион", "one million"},
+		{"1E+36", "1000000000000000000000000000000000000", "1000000000000000000000000000000000000"},
+		{"999999999999999999999999999999999999", "девятьсот девяносто девять дециллионов девятьсот девяносто девять нониллионов девятьсот девяносто девять октиллионов девятьсот девяносто девять септиллионов девятьсот девяносто девять секстиллионов девятьсот девяносто девять квинтиллионов девятьсот девяносто девять квадриллионов девятьсот девяносто девять триллионов девятьсот девяносто девять миллиардов девятьсот девяносто девять миллионов девятьсот девяносто девять тысяч девятьсот девяносто девять", ""},
+		{"0.00000000000009", "ноль целых девять стотриллионных", ""},
+		{"0.000000000000009", "ноль целых одна стотриллионная", ""},
+		{"0.0000000000000001", "ноль", "zero"},
+	} {
+		d := N(testCase.d)
+		if s := d.InWordsRU(); s != testCase.ru {
+			t.Errorf("%s: expected %q, got %q", testCase.d, testCase.ru, s)
+		}
+		if s := d.InWordsEN(); testCase.en != "" && s != testCase.en {
+			t.Errorf("%s: expected %q, got %q", testCase.d, testCase.en, s)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Package cbor содержит общую для пакетов types и decimal реализацию
+// записи и чтения заголовков элементов CBOR (RFC 8949)
+package cbor
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Основные типы элементов CBOR
+const (
+	MajorUint   = 0
+	MajorNegInt = 1
+	MajorBytes  = 2
+	MajorText   = 3
+	MajorArray  = 4
+	MajorTag    = 6
+	MajorSimple = 7
+)
+
+// Error описывает ошибку чтения заголовка элемента CBOR.
+// Текст ошибки формируют вызывающие пакеты
+type Error struct {
+	// Unsupported равно true, если заголовок не поддерживается
+	// (например, элемент неопределённой длины), иначе данные обрезаны
+	Unsupported bool
+	// InitialByte - первый байт неподдерживаемого заголовка
+	InitialByte byte
+}
+
+// Error реализует интерфейс error
+func (e *Error) Error() string {
+	if e.Unsupported {
+		return "invalid CBOR: unsupported initial byte"
+	}
+	return "invalid CBOR: unexpected end of data"
+}
+
+// AppendHead добавляет к b заголовок элемента CBOR основного типа major с аргументом n
+func AppendHead(b []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(b, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(b, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, major|27), n)
+	}
+}
+
+// ReadHead читает заголовок элемента CBOR: основной тип, дополнительную
+// информацию и аргумент. Элементы неопределённой длины не поддерживаются.
+// Возвращает *Error, если данные обрезаны или заголовок не поддерживается
+func ReadHead(data []byte) (major, info byte, n uint64, rest []byte, err error) {
+	if len(data) == 0 {
+		return 0, 0, 0, nil, &Error{}
+	}
+	major, info = data[0]>>5, data[0]&31
+	switch {
+	case info < 24:
+		return major, info, uint64(info), data[1:], nil
+	case info <= 27:
+		size := 1 << (info - 24)
+		if len(data) < 1+size {
+			return 0, 0, 0, nil, &Error{}
+		}
+		for _, c := range data[1 : 1+size] {
+			n = n<<8 | uint64(c)
+		}
+		return major, info, n, data[1+size:], nil
+	default:
+		return 0, 0, 0, nil, &Error{Unsupported: true, InitialByte: data[0]}
+	}
+}
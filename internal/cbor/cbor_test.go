@@ -0,0 +1,59 @@
+package cbor
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestHeadRoundTrip(t *testing.T) {
+	tests := []struct {
+		major    byte
+		n        uint64
+		expected []byte
+	}{
+		{MajorUint, 10, []byte{0x0a}},
+		{MajorNegInt, 24, []byte{0x38, 0x18}},
+		{MajorText, 256, []byte{0x79, 0x01, 0x00}},
+		{MajorTag, 1004, []byte{0xd9, 0x03, 0xec}},
+		{MajorArray, 1 << 16, []byte{0x9a, 0x00, 0x01, 0x00, 0x00}},
+		{MajorUint, 1 << 32, []byte{0x1b, 0, 0, 0, 1, 0, 0, 0, 0}},
+	}
+	for _, test := range tests {
+		b := AppendHead(nil, test.major, test.n)
+		if !reflect.DeepEqual(b, test.expected) {
+			t.Fatalf("Ожидалось %x, получено %x", test.expected, b)
+		}
+		major, _, n, rest, err := ReadHead(append(b, 0xff))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if major != test.major || n != test.n || !reflect.DeepEqual(rest, []byte{0xff}) {
+			t.Fatalf("%x: ожидалось %d %d, получено %d %d %x", b, test.major, test.n, major, n, rest)
+		}
+	}
+
+	if _, info, _, _, err := ReadHead([]byte{0xfb, 0, 0, 0, 0, 0, 0, 0, 0}); err != nil || info != 27 {
+		t.Fatalf("Ожидалась дополнительная информация 27, получено %d, %v", info, err)
+	}
+}
+
+func TestReadHeadErrors(t *testing.T) {
+	tests := []struct {
+		data     []byte
+		expected Error
+	}{
+		{nil, Error{}},
+		{[]byte{0x18}, Error{}},
+		{[]byte{0x1b, 0, 0, 0}, Error{}},
+		{[]byte{0x1c}, Error{Unsupported: true, InitialByte: 0x1c}},
+		{[]byte{0x9f}, Error{Unsupported: true, InitialByte: 0x9f}},
+	}
+	for _, test := range tests {
+		_, _, _, _, err := ReadHead(test.data)
+		var e *Error
+		if !errors.As(err, &e) || *e != test.expected {
+			t.Fatalf("%x: ожидалось %+v, получено %v", test.data, test.expected, err)
+		}
+	}
+}
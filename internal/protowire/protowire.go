@@ -0,0 +1,104 @@
+// Package protowire содержит общую для пакетов types и decimal реализацию
+// чтения и записи полей в двоичном формате protobuf
+package protowire
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Типы значений в двоичном формате protobuf
+const (
+	WireVarint  = 0
+	WireFixed64 = 1
+	WireBytes   = 2
+	WireFixed32 = 5
+)
+
+// Error описывает ошибку разбора сообщения protobuf.
+// Текст ошибки формируют вызывающие пакеты
+type Error struct {
+	// Field - номер поля, 0 если не удалось прочитать ключ поля
+	Field int
+	// WireType - тип значения поля
+	WireType uint64
+	// Unsupported равно true, если тип значения не поддерживается,
+	// иначе данные поля обрезаны или повреждены
+	Unsupported bool
+}
+
+// Error реализует интерфейс error
+func (e *Error) Error() string {
+	switch {
+	case e.Field == 0:
+		return "invalid protobuf message: bad field key"
+	case e.Unsupported:
+		return "invalid protobuf message: unsupported wire type"
+	default:
+		return "invalid protobuf message: bad field"
+	}
+}
+
+// AppendVarint добавляет к b поле field с целым значением v,
+// нулевые значения не записываются, как принято в proto3
+func AppendVarint(b []byte, field int, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = binary.AppendUvarint(b, uint64(field)<<3|WireVarint)
+	return binary.AppendUvarint(b, v)
+}
+
+// AppendString добавляет к b строковое поле field,
+// пустые строки не записываются, как принято в proto3
+func AppendString(b []byte, field int, s string) []byte {
+	if s == "" {
+		return b
+	}
+	b = binary.AppendUvarint(b, uint64(field)<<3|WireBytes)
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+// Read разбирает сообщение data и вызывает set для каждого целого поля
+// и поля с длиной, поля фиксированного размера пропускаются.
+// Возвращает *Error, если данные обрезаны, номер поля неверен
+// или тип значения не поддерживается
+func Read(data []byte, set func(field int, v uint64, b []byte)) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 || key>>3 == 0 || key>>3 > math.MaxInt32 {
+			return &Error{}
+		}
+		data = data[n:]
+		field, wireType := int(key>>3), key&7
+		switch wireType {
+		case WireVarint:
+			v, n := binary.Uvarint(data)
+			if n <= 0 {
+				return &Error{Field: field, WireType: wireType}
+			}
+			data = data[n:]
+			set(field, v, nil)
+		case WireBytes:
+			l, n := binary.Uvarint(data)
+			if n <= 0 || l > uint64(len(data)-n) {
+				return &Error{Field: field, WireType: wireType}
+			}
+			set(field, 0, data[n:n+int(l)])
+			data = data[n+int(l):]
+		case WireFixed64, WireFixed32:
+			size := 8
+			if wireType == WireFixed32 {
+				size = 4
+			}
+			if len(data) < size {
+				return &Error{Field: field, WireType: wireType}
+			}
+			data = data[size:]
+		default:
+			return &Error{Field: field, WireType: wireType, Unsupported: true}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package protowire
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	b := AppendVarint(nil, 1, 150)
+	b = AppendVarint(b, 2, 0)
+	b = AppendString(b, 3, "RUB")
+	b = AppendString(b, 4, "")
+	if expected := []byte{0x08, 0x96, 0x01, 0x1a, 0x03, 'R', 'U', 'B'}; !reflect.DeepEqual(b, expected) {
+		t.Fatalf("Ожидалось %x, получено %x", expected, b)
+	}
+
+	// поля фиксированного размера пропускаются
+	b = append(b, 0x25, 1, 2, 3, 4, 0x29, 1, 2, 3, 4, 5, 6, 7, 8)
+	var fields []int
+	err := Read(b, func(field int, v uint64, s []byte) {
+		fields = append(fields, field)
+		if field == 1 && v != 150 || field == 3 && string(s) != "RUB" {
+			t.Fatalf("Неверное значение поля %d: %d %q", field, v, s)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fields, []int{1, 3}) {
+		t.Fatalf("Ожидались поля [1 3], получено %v", fields)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	tests := []struct {
+		data     []byte
+		expected Error
+	}{
+		{[]byte{0x80}, Error{}},
+		{[]byte{0x00, 0x01}, Error{}},
+		{[]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x01}, Error{}},
+		{[]byte{0x08}, Error{Field: 1, WireType: WireVarint}},
+		{[]byte{0x0a, 0x05, 'x'}, Error{Field: 1, WireType: WireBytes}},
+		{[]byte{0x0d, 0x01}, Error{Field: 1, WireType: WireFixed32}},
+		{[]byte{0x09, 0x01}, Error{Field: 1, WireType: WireFixed64}},
+		{[]byte{0x0b}, Error{Field: 1, WireType: 3, Unsupported: true}},
+	}
+	for _, test := range tests {
+		err := Read(test.data, func(int, uint64, []byte) {})
+		var e *Error
+		if !errors.As(err, &e) || *e != test.expected {
+			t.Fatalf("%x: ожидалось %+v, получено %v", test.data, test.expected, err)
+		}
+	}
+}
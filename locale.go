@@ -0,0 +1,435 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// GrammaticalCase задаёт падеж, в котором выводятся названия месяцев и дней недели
+type GrammaticalCase int
+
+// Поддерживаемые падежи
+const (
+	Nominative    GrammaticalCase = iota // именительный: "июль", "среда"
+	Genitive                             // родительный: "14 июля", "до среды"
+	Accusative                           // винительный: "в среду"
+	Prepositional                        // предложный: "в июле", "о среде"
+)
+
+// GrammaticalGender задаёт род, с которым согласуется порядковое числительное
+type GrammaticalGender int
+
+// Поддерживаемые роды
+const (
+	Masculine GrammaticalGender = iota // мужской: "1-й день"
+	Feminine                           // женский: "1-я неделя"
+	Neuter                             // средний: "1-е число"
+)
+
+// russianOrdinalEndings хранит наращения русских порядковых числительных
+// по падежам и родам: "1-й", "1-го", "1-я", "1-е"
+var russianOrdinalEndings = [4][3]string{
+	{"й", "я", "е"},   // именительный
+	{"го", "й", "го"}, // родительный
+	{"й", "ю", "е"},   // винительный (для неодушевлённых)
+	{"м", "й", "м"},   // предложный
+}
+
+// Единицы измерения времени для относительных фраз
+const (
+	unitSecond = iota
+	unitMinute
+	unitHour
+	unitDay
+	unitMonth
+	unitYear
+)
+
+// Locale хранит правила вывода дат на естественном языке:
+// названия месяцев и дней недели в разных падежах, порядковые числительные
+// и фразы относительного времени ("3 дня назад", "in 2 hours")
+type Locale struct {
+	months        [4][12]string
+	monthsShort   [12]string
+	weekdays      [4][7]string
+	weekdaysShort [7]string
+	units         [6][3]string
+	future        string
+	past          string
+	justNow       string
+	today         string
+	yesterday     string
+	tomorrow      string
+	plural        func(n int) int
+	ordinal       func(n int, c GrammaticalCase, g GrammaticalGender) string
+	date          func(l *Locale, t time.Time) string
+}
+
+// LocaleRU - русская локаль
+var LocaleRU = &Locale{
+	months: [4][12]string{
+		{"январь", "февраль", "март", "апрель", "май", "июнь", "июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь"},
+		{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"},
+		{"январь", "февраль", "март", "апрель", "май", "июнь", "июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь"},
+		{"январе", "феврале", "марте", "апреле", "мае", "июне", "июле", "августе", "сентябре", "октябре", "ноябре", "декабре"},
+	},
+	monthsShort: [12]string{"янв", "фев", "мар", "апр", "май", "июн", "июл", "авг", "сен", "окт", "ноя", "дек"},
+	weekdays: [4][7]string{
+		{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"},
+		{"воскресенья", "понедельника", "вторника", "среды", "четверга", "пятницы", "субботы"},
+		{"воскресенье", "понедельник", "вторник", "среду", "четверг", "пятницу", "субботу"},
+		{"воскресенье", "понедельнике", "вторнике", "среде", "четверге", "пятнице", "субботе"},
+	},
+	weekdaysShort: [7]string{"вс", "пн", "вт", "ср", "чт", "пт", "сб"},
+	units: [6][3]string{
+		{"секунду", "секунды", "секунд"},
+		{"минуту", "минуты", "минут"},
+		{"час", "часа", "часов"},
+		{"день", "дня", "дней"},
+		{"месяц", "месяца", "месяцев"},
+		{"год", "года", "лет"},
+	},
+	future:    "через %s",
+	past:      "%s назад",
+	justNow:   "только что",
+	today:     "сегодня",
+	yesterday: "вчера",
+	tomorrow:  "завтра",
+	plural:    russianPlural,
+	ordinal: func(n int, c GrammaticalCase, g GrammaticalGender) string {
+		return strconv.Itoa(n) + "-" + russianOrdinalEndings[c.index()][g.index()]
+	},
+	date: func(l *Locale, t time.Time) string {
+		return fmt.Sprintf("%d %s %d г.", t.Day(), l.MonthName(t.Month(), Genitive), t.Year())
+	},
+}
+
+// LocaleEN - английская локаль
+var LocaleEN = &Locale{
+	months: [4][12]string{
+		{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	},
+	monthsShort: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	weekdays: [4][7]string{
+		{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	},
+	weekdaysShort: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	units: [6][3]string{
+		{"second", "seconds", "seconds"},
+		{"minute", "minutes", "minutes"},
+		{"hour", "hours", "hours"},
+		{"day", "days", "days"},
+		{"month", "months", "months"},
+		{"year", "years", "years"},
+	},
+	future:    "in %s",
+	past:      "%s ago",
+	justNow:   "just now",
+	today:     "today",
+	yesterday: "yesterday",
+	tomorrow:  "tomorrow",
+	plural: func(n int) int {
+		if n == 1 {
+			return 0
+		}
+		return 1
+	},
+	ordinal: func(n int, _ GrammaticalCase, _ GrammaticalGender) string {
+		return englishOrdinal(n)
+	},
+	date: func(l *Locale, t time.Time) string {
+		return fmt.Sprintf("%s %d, %d", l.MonthName(t.Month(), Nominative), t.Day(), t.Year())
+	},
+}
+
+// russianPlural возвращает номер формы существительного для числа n:
+// 0 - "1 день", 1 - "2 дня", 2 - "5 дней"
+func russianPlural(n int) int {
+	if n < 0 {
+		n = -n
+	}
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return 0
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return 1
+	}
+	return 2
+}
+
+// englishOrdinal возвращает английское порядковое числительное: 1st, 2nd, 3rd, 11th
+func englishOrdinal(n int) string {
+	suffix := "th"
+	if m := n % 100; m < 11 || m > 13 {
+		switch n % 10 {
+		case 1, -1:
+			suffix = "st"
+		case 2, -2:
+			suffix = "nd"
+		case 3, -3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// index возвращает номер падежа c, неизвестный падеж считается именительным
+func (c GrammaticalCase) index() int {
+	if c < Nominative || c > Prepositional {
+		return int(Nominative)
+	}
+	return int(c)
+}
+
+// index возвращает номер рода g, неизвестный род считается мужским
+func (g GrammaticalGender) index() int {
+	if g < Masculine || g > Neuter {
+		return int(Masculine)
+	}
+	return int(g)
+}
+
+// MonthName возвращает название месяца month в падеже c.
+// Для несуществующего месяца, как и time.Month.String, возвращает "%!Month(13)"
+func (l *Locale) MonthName(month time.Month, c GrammaticalCase) string {
+	if month < time.January || month > time.December {
+		return month.String()
+	}
+	return l.months[c.index()][month-1]
+}
+
+// MonthShortName возвращает сокращённое название месяца month
+func (l *Locale) MonthShortName(month time.Month) string {
+	if month < time.January || month > time.December {
+		return month.String()
+	}
+	return l.monthsShort[month-1]
+}
+
+// WeekdayName возвращает название дня недели weekday в падеже c.
+// Для несуществующего дня недели, как и time.Weekday.String, возвращает "%!Weekday(7)"
+func (l *Locale) WeekdayName(weekday time.Weekday, c GrammaticalCase) string {
+	if weekday < time.Sunday || weekday > time.Saturday {
+		return weekday.String()
+	}
+	return l.weekdays[c.index()][weekday]
+}
+
+// WeekdayShortName возвращает сокращённое название дня недели weekday
+func (l *Locale) WeekdayShortName(weekday time.Weekday) string {
+	if weekday < time.Sunday || weekday > time.Saturday {
+		return weekday.String()
+	}
+	return l.weekdaysShort[weekday]
+}
+
+// Ordinal возвращает порядковое числительное для числа n в падеже c и роде g:
+// "2-й", "2-го", "2-я", "2-е" или "2nd". В английской локали падеж и род не учитываются
+func (l *Locale) Ordinal(n int, c GrammaticalCase, g GrammaticalGender) string {
+	return l.ordinal(n, c, g)
+}
+
+// Plural возвращает число n и согласованную с ним форму forms:
+// для русской локали forms задаются для 1, 2 и 5 ("день", "дня", "дней"),
+// для английской - для 1 и остальных чисел ("day", "days")
+func (l *Locale) Plural(n int, forms ...string) string {
+	i := l.plural(n)
+	if i >= len(forms) {
+		i = len(forms) - 1
+	}
+	return fmt.Sprintf("%d %s", n, forms[i])
+}
+
+// FormatDate возвращает дату d на естественном языке: "14 июля 2017 г." или "July 14, 2017"
+func (l *Locale) FormatDate(d Date) string {
+	return l.date(l, d.Time)
+}
+
+// FormatDateWeekday возвращает дату d с днём недели: "пятница, 14 июля 2017 г."
+func (l *Locale) FormatDateWeekday(d Date) string {
+	return l.WeekdayName(d.Weekday(), Nominative) + ", " + l.date(l, d.Time)
+}
+
+// FormatDateTime возвращает дату-время d на естественном языке: "14 июля 2017 г., 15:04"
+func (l *Locale) FormatDateTime(d DateTime) string {
+	return l.date(l, d.Time) + ", " + d.Time.Format("15:04")
+}
+
+// FormatYearMonth возвращает месяц ym на естественном языке: "июль 2017" или "July 2017"
+func (l *Locale) FormatYearMonth(ym YearMonth) string {
+	return fmt.Sprintf("%s %d", l.MonthName(ym.Month, Nominative), ym.Year)
+}
+
+// relative возвращает фразу "через n единиц" или "n единиц назад"
+func (l *Locale) relative(n int, unit int) string {
+	if n < 0 {
+		return fmt.Sprintf(l.past, l.Plural(-n, l.units[unit][:]...))
+	}
+	return fmt.Sprintf(l.future, l.Plural(n, l.units[unit][:]...))
+}
+
+// RelativeDate возвращает дату d относительно даты now: "сегодня", "вчера",
+// "через 3 дня", "2 months ago"
+func (l *Locale) RelativeDate(d, now Date) string {
+	days := now.DaysBefore(d)
+	switch days {
+	case 0:
+		return l.today
+	case -1:
+		return l.yesterday
+	case 1:
+		return l.tomorrow
+	}
+	return l.relativeDays(days, now.Time, d.Time)
+}
+
+// relativeDays возвращает фразу для разницы в days дней между from и to,
+// при большой разнице выражая её в месяцах или годах
+func (l *Locale) relativeDays(days int, from, to time.Time) string {
+	abs := days
+	if abs < 0 {
+		abs = -abs
+	}
+	if abs < 30 {
+		return l.relative(days, unitDay)
+	}
+	return l.relativeMonths(from, to)
+}
+
+// relativeMonths возвращает фразу для разницы между from и to в месяцах или годах
+func (l *Locale) relativeMonths(from, to time.Time) string {
+	sign := 1
+	if to.Before(from) {
+		sign = -1
+		from, to = to, from
+	}
+	months := (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+	if addMonths(from, months).After(to) {
+		months--
+	}
+	if months < 1 {
+		months = 1
+	}
+	if months < 12 {
+		return l.relative(sign*months, unitMonth)
+	}
+	return l.relative(sign*(months/12), unitYear)
+}
+
+// Relative возвращает дату-время d относительно даты-времени now:
+// "только что", "через 2 часа", "3 days ago", "вчера"
+func (l *Locale) Relative(d, now DateTime) string {
+	diff := d.Sub(now.Time)
+	abs := diff
+	if abs < 0 {
+		abs = -abs
+	}
+	sign := 1
+	if diff < 0 {
+		sign = -1
+	}
+	switch {
+	case abs < 45*time.Second:
+		return l.justNow
+	case abs < time.Minute:
+		return l.relative(sign*int(abs/time.Second), unitSecond)
+	case abs < time.Hour:
+		return l.relative(sign*int(abs/time.Minute), unitMinute)
+	case abs < 24*time.Hour:
+		return l.relative(sign*int(abs/time.Hour), unitHour)
+	}
+	days := now.ConvertToDate().DaysBefore(d.ConvertToDate())
+	switch days {
+	case -1:
+		return l.yesterday
+	case 1:
+		return l.tomorrow
+	}
+	return l.relativeDays(days, now.Time, d.Time)
+}
+
+// ParseDate формирует объект Date на основе строки s, содержащей дату
+// на естественном языке: "14 июля 2017 г.", "14 июль 2017", "July 14, 2017",
+// "14 Jul 2017". Регистр букв и день недели в строке не учитываются.
+func (l *Locale) ParseDate(s string) (Date, error) {
+	var day, year int
+	var month time.Month
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return unicode.IsSpace(r) || r == ',' || r == '.'
+	})
+	for _, field := range fields {
+		if n, err := strconv.Atoi(field); err == nil {
+			if len(field) == 4 && year == 0 {
+				year = n
+			} else if day == 0 {
+				day = n
+			} else {
+				return Date{}, fmt.Errorf("Неверный формат даты: %q", s)
+			}
+			continue
+		}
+		if m := l.lookupMonth(field); m != 0 {
+			if month != 0 {
+				return Date{}, fmt.Errorf("Неверный формат даты: %q", s)
+			}
+			month = m
+			continue
+		}
+		if field == "г" || field == "года" || l.isWeekday(field) {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimRight(field, "-йеяюгомstndrh")); err == nil && day == 0 {
+			day = n
+			continue
+		}
+		return Date{}, fmt.Errorf("Неверный формат даты: %q", s)
+	}
+	if day == 0 || month == 0 || year == 0 {
+		return Date{}, fmt.Errorf("Неверный формат даты: %q", s)
+	}
+	t := time.Date(year, month, day, 0, 0, 0, 0, defaultLocation)
+	if t.Day() != day {
+		return Date{}, fmt.Errorf("Неверная дата: %q", s)
+	}
+	return ToDate(t), nil
+}
+
+// lookupMonth возвращает месяц по его названию в любом падеже или сокращению,
+// либо 0, если название не найдено
+func (l *Locale) lookupMonth(name string) time.Month {
+	for m := 0; m < 12; m++ {
+		for c := range l.months {
+			if strings.ToLower(l.months[c][m]) == name {
+				return time.Month(m + 1)
+			}
+		}
+		if strings.ToLower(l.monthsShort[m]) == name {
+			return time.Month(m + 1)
+		}
+	}
+	return 0
+}
+
+// isWeekday проверяет, является ли name названием дня недели
+func (l *Locale) isWeekday(name string) bool {
+	for w := 0; w < 7; w++ {
+		for c := range l.weekdays {
+			if strings.ToLower(l.weekdays[c][w]) == name {
+				return true
+			}
+		}
+		if strings.ToLower(l.weekdaysShort[w]) == name {
+			return true
+		}
+	}
+	return false
+}
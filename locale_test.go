@@ -0,0 +1,161 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocaleFormatDate(t *testing.T) {
+	d, err := StringToDate("2017-07-14")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dt, err := StringToDateTime("2017-07-14 15:04:05")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		received, expected string
+	}{
+		{LocaleRU.FormatDate(d), "14 июля 2017 г."},
+		{LocaleEN.FormatDate(d), "July 14, 2017"},
+		{LocaleRU.FormatDateWeekday(d), "пятница, 14 июля 2017 г."},
+		{LocaleEN.FormatDateWeekday(d), "Friday, July 14, 2017"},
+		{LocaleRU.FormatDateTime(dt), "14 июля 2017 г., 15:04"},
+		{LocaleRU.FormatYearMonth(NewYearMonth(2017, time.July)), "июль 2017"},
+		{LocaleEN.FormatYearMonth(NewYearMonth(2017, time.July)), "July 2017"},
+		{LocaleRU.MonthName(time.May, Prepositional), "мае"},
+		{LocaleRU.WeekdayName(time.Wednesday, Accusative), "среду"},
+		{LocaleRU.WeekdayName(time.Wednesday, Genitive), "среды"},
+		{LocaleRU.WeekdayShortName(time.Monday), "пн"},
+		{LocaleEN.MonthShortName(time.September), "Sep"},
+		{LocaleRU.MonthName(0, Nominative), "%!Month(0)"},
+		{LocaleRU.MonthName(13, Genitive), "%!Month(13)"},
+		{LocaleEN.MonthShortName(13), "%!Month(13)"},
+		{LocaleRU.MonthName(time.July, GrammaticalCase(10)), "июль"},
+		{LocaleRU.WeekdayName(7, Nominative), "%!Weekday(7)"},
+		{LocaleRU.WeekdayName(-1, Nominative), time.Weekday(-1).String()},
+		{LocaleRU.WeekdayShortName(7), "%!Weekday(7)"},
+		{LocaleRU.WeekdayName(time.Wednesday, GrammaticalCase(-1)), "среда"},
+		{LocaleRU.Ordinal(1, GrammaticalCase(10), GrammaticalGender(10)), "1-й"},
+	}
+	for _, test := range tests {
+		if test.received != test.expected {
+			t.Fatalf("Ожидалось %q, получено %q", test.expected, test.received)
+		}
+	}
+}
+
+func TestLocaleOrdinalPlural(t *testing.T) {
+	ordinals := map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 21: "21st", 102: "102nd", 111: "111th"}
+	for n, expected := range ordinals {
+		if s := LocaleEN.Ordinal(n, Genitive, Feminine); s != expected {
+			t.Fatalf("Ожидалось %s, получено %s", expected, s)
+		}
+	}
+	for _, test := range []struct {
+		c        GrammaticalCase
+		g        GrammaticalGender
+		expected string
+	}{
+		{Nominative, Masculine, "2-й"},
+		{Nominative, Feminine, "2-я"},
+		{Nominative, Neuter, "2-е"},
+		{Genitive, Masculine, "2-го"},
+		{Genitive, Feminine, "2-й"},
+		{Genitive, Neuter, "2-го"},
+		{Accusative, Feminine, "2-ю"},
+		{Prepositional, Masculine, "2-м"},
+		{Prepositional, Feminine, "2-й"},
+	} {
+		if s := LocaleRU.Ordinal(2, test.c, test.g); s != test.expected {
+			t.Fatalf("Ожидалось %s, получено %s", test.expected, s)
+		}
+	}
+
+	plurals := map[int]string{0: "0 дней", 1: "1 день", 2: "2 дня", 4: "4 дня", 5: "5 дней", 11: "11 дней", 12: "12 дней", 14: "14 дней", 21: "21 день", 22: "22 дня", 111: "111 дней", 101: "101 день"}
+	for n, expected := range plurals {
+		if s := LocaleRU.Plural(n, "день", "дня", "дней"); s != expected {
+			t.Fatalf("Ожидалось %s, получено %s", expected, s)
+		}
+	}
+	if s := LocaleEN.Plural(1, "day", "days"); s != "1 day" {
+		t.Fatalf("Ожидалось 1 day, получено %s", s)
+	}
+	if s := LocaleEN.Plural(2, "day", "days"); s != "2 days" {
+		t.Fatalf("Ожидалось 2 days, получено %s", s)
+	}
+}
+
+func TestLocaleRelative(t *testing.T) {
+	now, err := StringToDateTime("2017-07-14 12:00:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		period Period
+		ru, en string
+	}{
+		{NewPeriod(0, 0, 0, 10*time.Second), "только что", "just now"},
+		{NewPeriod(0, 0, 0, -50*time.Second), "50 секунд назад", "50 seconds ago"},
+		{NewPeriod(0, 0, 0, time.Minute), "через 1 минуту", "in 1 minute"},
+		{NewPeriod(0, 0, 0, 2*time.Hour), "через 2 часа", "in 2 hours"},
+		{NewPeriod(0, 0, 0, -5*time.Hour), "5 часов назад", "5 hours ago"},
+		{NewPeriod(0, 0, -1, 0), "вчера", "yesterday"},
+		{NewPeriod(0, 0, 1, 0), "завтра", "tomorrow"},
+		{NewPeriod(0, 0, -3, 0), "3 дня назад", "3 days ago"},
+		{NewPeriod(0, 0, 21, 0), "через 21 день", "in 21 days"},
+		{NewPeriod(0, -2, 0, 0), "2 месяца назад", "2 months ago"},
+		{NewPeriod(0, 0, 45, 0), "через 1 месяц", "in 1 month"},
+		{NewPeriod(5, 0, 0, 0), "через 5 лет", "in 5 years"},
+		{NewPeriod(-1, -1, 0, 0), "1 год назад", "1 year ago"},
+	}
+	for _, test := range tests {
+		dt := now.AddPeriod(test.period)
+		if s := LocaleRU.Relative(dt, now); s != test.ru {
+			t.Fatalf("%s: ожидалось %q, получено %q", test.period, test.ru, s)
+		}
+		if s := LocaleEN.Relative(dt, now); s != test.en {
+			t.Fatalf("%s: ожидалось %q, получено %q", test.period, test.en, s)
+		}
+	}
+
+	today := now.ConvertToDate()
+	dates := map[int]string{0: "сегодня", -1: "вчера", 1: "завтра", 2: "через 2 дня", -25: "25 дней назад", 400: "через 1 год"}
+	for days, expected := range dates {
+		if s := LocaleRU.RelativeDate(today.Add(0, 0, days), today); s != expected {
+			t.Fatalf("%d: ожидалось %q, получено %q", days, expected, s)
+		}
+	}
+}
+
+func TestLocaleParseDate(t *testing.T) {
+	tests := []struct {
+		locale *Locale
+		s      string
+	}{
+		{LocaleRU, "14 июля 2017 г."},
+		{LocaleRU, "14 Июль 2017"},
+		{LocaleRU, "пятница, 14 июля 2017 года"},
+		{LocaleRU, "14-го июля 2017 года"},
+		{LocaleRU, "14 июл. 2017"},
+		{LocaleEN, "July 14, 2017"},
+		{LocaleEN, "Friday, July 14th, 2017"},
+		{LocaleEN, "14 jul 2017"},
+	}
+	for _, test := range tests {
+		d, err := test.locale.ParseDate(test.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := d.String(); s != "2017-07-14" {
+			t.Fatalf("%q: ожидалось 2017-07-14, получено %s", test.s, s)
+		}
+	}
+
+	for _, s := range []string{"", "14 2017", "июля 2017", "31 февраля 2017", "14 июля июня 2017", "14 foo 2017"} {
+		if _, err := LocaleRU.ParseDate(s); err == nil {
+			t.Fatalf("%q: ожидалась ошибка", s)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package types
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Элементы формата MessagePack, используемые для времени.
+// Время кодируется стандартным расширением timestamp (тип -1)
+// в наиболее компактном из форматов timestamp 32, 64 и 96
+const (
+	msgpackNil           = 0xc0
+	msgpackExt8          = 0xc7
+	msgpackFixExt4       = 0xd6
+	msgpackFixExt8       = 0xd7
+	msgpackTimestampType = 0xff
+)
+
+// appendMsgpackTime добавляет к b время t в виде расширения timestamp
+func appendMsgpackTime(b []byte, t time.Time) []byte {
+	sec, nsec := t.Unix(), uint64(t.Nanosecond())
+	switch {
+	case sec >= 0 && sec < 1<<32 && nsec == 0:
+		b = append(b, msgpackFixExt4, msgpackTimestampType)
+		return binary.BigEndian.AppendUint32(b, uint32(sec))
+	case sec >= 0 && sec < 1<<34:
+		b = append(b, msgpackFixExt8, msgpackTimestampType)
+		return binary.BigEndian.AppendUint64(b, nsec<<34|uint64(sec))
+	default:
+		b = append(b, msgpackExt8, 12, msgpackTimestampType)
+		b = binary.BigEndian.AppendUint32(b, uint32(nsec))
+		return binary.BigEndian.AppendUint64(b, uint64(sec))
+	}
+}
+
+// readMsgpackTime разбирает расширение timestamp из data в часовом поясе по умолчанию.
+// Для значения nil возвращается null = true
+func readMsgpackTime(data []byte) (t time.Time, null bool, err error) {
+	if len(data) == 1 && data[0] == msgpackNil {
+		return t, true, nil
+	}
+	var payload []byte
+	switch {
+	case len(data) == 6 && data[0] == msgpackFixExt4 && data[1] == msgpackTimestampType:
+		payload = data[2:]
+	case len(data) == 10 && data[0] == msgpackFixExt8 && data[1] == msgpackTimestampType:
+		payload = data[2:]
+	case len(data) == 15 && data[0] == msgpackExt8 && data[1] == 12 && data[2] == msgpackTimestampType:
+		payload = data[3:]
+	default:
+		return t, false, fmt.Errorf("Неверный формат MessagePack: ожидалось расширение timestamp, получено % x", data)
+	}
+
+	var sec, nsec int64
+	switch len(payload) {
+	case 4:
+		sec = int64(binary.BigEndian.Uint32(payload))
+	case 8:
+		v := binary.BigEndian.Uint64(payload)
+		sec, nsec = int64(v&(1<<34-1)), int64(v>>34)
+	default:
+		nsec = int64(binary.BigEndian.Uint32(payload))
+		sec = int64(binary.BigEndian.Uint64(payload[4:]))
+	}
+	if nsec >= 1e9 {
+		return t, false, fmt.Errorf("Неверный формат MessagePack: %d наносекунд в timestamp", nsec)
+	}
+	return time.Unix(sec, nsec).In(defaultLocation), false, nil
+}
+
+// MarshalMsgpack преобразует DateTime в MessagePack (расширение timestamp)
+// Совместим с интерфейсом msgpack.Marshaler
+func (d DateTime) MarshalMsgpack() ([]byte, error) {
+	return appendMsgpackTime(nil, d.Time), nil
+}
+
+// UnmarshalMsgpack разбирает DateTime из MessagePack (расширение timestamp)
+// Совместим с интерфейсом msgpack.Unmarshaler
+func (d *DateTime) UnmarshalMsgpack(data []byte) error {
+	t, null, err := readMsgpackTime(data)
+	if err != nil {
+		return err
+	}
+	if null {
+		return errors.New("Ошибка UnmarshalMsgpack: значение nil не может быть записано в DateTime")
+	}
+	d.fixLayout()
+	d.setTime(t)
+	return nil
+}
+
+// MarshalMsgpack преобразует Date в MessagePack (расширение timestamp начала дня)
+// Совместим с интерфейсом msgpack.Marshaler
+func (d Date) MarshalMsgpack() ([]byte, error) {
+	return appendMsgpackTime(nil, d.Time), nil
+}
+
+// UnmarshalMsgpack разбирает Date из MessagePack (расширение timestamp),
+// время отбрасывается
+// Совместим с интерфейсом msgpack.Unmarshaler
+func (d *Date) UnmarshalMsgpack(data []byte) error {
+	t, null, err := readMsgpackTime(data)
+	if err != nil {
+		return err
+	}
+	if null {
+		return errors.New("Ошибка UnmarshalMsgpack: значение nil не может быть записано в Date")
+	}
+	d.fixLayout()
+	d.setTime(ToDate(t).Time)
+	return nil
+}
+
+// MarshalMsgpack преобразует NullDateTime в MessagePack,
+// значение NULL преобразуется в nil
+func (d NullDateTime) MarshalMsgpack() ([]byte, error) {
+	if !d.Valid {
+		return []byte{msgpackNil}, nil
+	}
+	return d.DateTime.MarshalMsgpack()
+}
+
+// UnmarshalMsgpack разбирает NullDateTime из MessagePack,
+// nil преобразуется в значение NULL
+func (d *NullDateTime) UnmarshalMsgpack(data []byte) error {
+	if len(data) == 1 && data[0] == msgpackNil {
+		d.Valid = false
+		return nil
+	}
+	err := d.DateTime.UnmarshalMsgpack(data)
+	d.Valid = err == nil
+	return err
+}
+
+// MarshalMsgpack преобразует NullDate в MessagePack,
+// значение NULL преобразуется в nil
+func (d NullDate) MarshalMsgpack() ([]byte, error) {
+	if !d.Valid {
+		return []byte{msgpackNil}, nil
+	}
+	return d.Date.MarshalMsgpack()
+}
+
+// UnmarshalMsgpack разбирает NullDate из MessagePack,
+// nil преобразуется в значение NULL
+func (d *NullDate) UnmarshalMsgpack(data []byte) error {
+	if len(data) == 1 && data[0] == msgpackNil {
+		d.Valid = false
+		return nil
+	}
+	err := d.Date.UnmarshalMsgpack(data)
+	d.Valid = err == nil
+	return err
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+)
+
+func TestDateTimeMsgpack(t *testing.T) {
+	tests := []struct {
+		t   time.Time
+		hex string
+	}{
+		{time.Unix(0, 0), "d6ff00000000"},
+		{time.Unix(1500000000, 0), "d6ff59682f00"},
+		{time.Unix(1500000000, 123456789), "d7ff1d6f345459682f00"},
+		{time.Unix(1<<32, 0), "d7ff0000000100000000"},
+		{time.Unix(-1, 0), "c70cff00000000ffffffffffffffff"},
+		{time.Unix(-1, 500), "c70cff000001f4ffffffffffffffff"},
+	}
+	for _, test := range tests {
+		dt := DateTime{Time: test.t, Layout: DateTimeLayout}
+		b, err := dt.MarshalMsgpack()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := hex.EncodeToString(b); s != test.hex {
+			t.Fatalf("%v: ожидалось %s, получено %s", test.t, test.hex, s)
+		}
+		dt2 := DateTime{Layout: GraphsDateLayout}
+		if err := dt2.UnmarshalMsgpack(b); err != nil {
+			t.Fatal(err)
+		}
+		if !dt2.Equal(dt) || dt2.Location() != defaultLocation || dt2.Layout != GraphsDateLayout {
+			t.Fatalf("Ожидалось %v, получено %#v", dt.Time, dt2)
+		}
+	}
+
+	for _, s := range []string{"c0", "", "d6ff0000", "d6fe00000000", "d7ff0000000000000000ff", "d7fffffffffc00000000", "a3616263"} {
+		data, _ := hex.DecodeString(s)
+		var dt DateTime
+		if err := dt.UnmarshalMsgpack(data); err == nil {
+			t.Fatalf("%s: ожидалась ошибка", s)
+		}
+	}
+}
+
+func TestDateNullMsgpack(t *testing.T) {
+	d, err := StringToDate("2017-07-14")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := d.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d2 Date
+	if err := d2.UnmarshalMsgpack(b); err != nil {
+		t.Fatal(err)
+	}
+	if !d2.Equal(d) || d2.String() != "2017-07-14" {
+		t.Fatalf("Ожидалось %v, получено %v", d, d2)
+	}
+
+	// время в середине дня отбрасывается
+	b, _ = DateTime{Time: d.Add(0, 0, 0).Time.Add(15 * time.Hour)}.MarshalMsgpack()
+	if err := d2.UnmarshalMsgpack(b); err != nil || !d2.Equal(d) {
+		t.Fatalf("Ожидалось %v, получено %v, %v", d, d2, err)
+	}
+
+	b, err = MakeNullDate().MarshalMsgpack()
+	if err != nil || hex.EncodeToString(b) != "c0" {
+		t.Fatalf("Ожидалось c0, получено %x, %v", b, err)
+	}
+	nd := d.Nullable()
+	if err := nd.UnmarshalMsgpack(b); err != nil || nd.Valid {
+		t.Fatalf("Ожидалось NULL, получено %v, %v", nd, err)
+	}
+	if err := d2.UnmarshalMsgpack(b); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+
+	dt := DateTime{Time: time.Unix(1500000000, 0)}.Nullable()
+	b, err = dt.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ndt := MakeNullDateTime()
+	if err := ndt.UnmarshalMsgpack(b); err != nil || !ndt.Valid || !ndt.Equal(dt.DateTime) {
+		t.Fatalf("Ожидалось %v, получено %v, %v", dt, ndt, err)
+	}
+	b, _ = MakeNullDateTime().MarshalMsgpack()
+	if err := ndt.UnmarshalMsgpack(b); err != nil || ndt.Valid {
+		t.Fatalf("Ожидалось NULL, получено %v, %v", ndt, err)
+	}
+}
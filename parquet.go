@@ -0,0 +1,107 @@
+package types
+
+import "time"
+
+// ParquetDate возвращает значение логического типа Parquet DATE для d:
+// число дней от 1970-01-01, дата берётся в часовом поясе объекта
+func (d Date) ParquetDate() int32 {
+	year, month, day := d.Date()
+	return int32(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / secondsInDay)
+}
+
+// ParquetDateToDate формирует объект типа Date на основе значения
+// Parquet DATE в часовом поясе по умолчанию и с шаблоном DateLayout
+func ParquetDateToDate(days int32) Date {
+	return ToDate(time.Date(1970, time.January, 1+int(days), 0, 0, 0, 0, defaultLocation))
+}
+
+// ParquetTimestampMillis возвращает значение логического типа Parquet
+// TIMESTAMP(MILLIS) с isAdjustedToUTC = true: число миллисекунд от начала
+// эпохи Unix. Доли миллисекунды отбрасываются
+func (d DateTime) ParquetTimestampMillis() int64 {
+	return d.UnixMilli()
+}
+
+// ParquetTimestampMicros возвращает значение логического типа Parquet
+// TIMESTAMP(MICROS) с isAdjustedToUTC = true: число микросекунд от начала
+// эпохи Unix. Доли микросекунды отбрасываются
+func (d DateTime) ParquetTimestampMicros() int64 {
+	return d.UnixMicro()
+}
+
+// ParquetTimestampMillisToDateTime формирует объект типа DateTime на основе
+// значения Parquet TIMESTAMP(MILLIS) в часовом поясе по умолчанию
+// и с шаблоном DateTimeLayout
+func ParquetTimestampMillisToDateTime(ms int64) DateTime {
+	dt := NewDateTime()
+	dt.setTime(time.UnixMilli(ms).In(defaultLocation))
+	return dt
+}
+
+// ParquetTimestampMicrosToDateTime формирует объект типа DateTime на основе
+// значения Parquet TIMESTAMP(MICROS) в часовом поясе по умолчанию
+// и с шаблоном DateTimeLayout
+func ParquetTimestampMicrosToDateTime(us int64) DateTime {
+	dt := NewDateTime()
+	dt.setTime(time.UnixMicro(us).In(defaultLocation))
+	return dt
+}
+
+// ParquetDate преобразует NullDate в значение необязательного столбца
+// Parquet DATE, значение NULL преобразуется в nil
+func (d NullDate) ParquetDate() *int32 {
+	if !d.Valid {
+		return nil
+	}
+	days := d.Date.ParquetDate()
+	return &days
+}
+
+// ParquetDateToNullDate формирует объект типа NullDate на основе значения
+// необязательного столбца Parquet DATE, nil преобразуется в значение NULL
+func ParquetDateToNullDate(days *int32) NullDate {
+	if days == nil {
+		return MakeNullDate()
+	}
+	return ParquetDateToDate(*days).Nullable()
+}
+
+// ParquetTimestampMillis преобразует NullDateTime в значение необязательного
+// столбца Parquet TIMESTAMP(MILLIS), значение NULL преобразуется в nil
+func (d NullDateTime) ParquetTimestampMillis() *int64 {
+	if !d.Valid {
+		return nil
+	}
+	ms := d.DateTime.ParquetTimestampMillis()
+	return &ms
+}
+
+// ParquetTimestampMicros преобразует NullDateTime в значение необязательного
+// столбца Parquet TIMESTAMP(MICROS), значение NULL преобразуется в nil
+func (d NullDateTime) ParquetTimestampMicros() *int64 {
+	if !d.Valid {
+		return nil
+	}
+	us := d.DateTime.ParquetTimestampMicros()
+	return &us
+}
+
+// ParquetTimestampMillisToNullDateTime формирует объект типа NullDateTime
+// на основе значения необязательного столбца Parquet TIMESTAMP(MILLIS),
+// nil преобразуется в значение NULL
+func ParquetTimestampMillisToNullDateTime(ms *int64) NullDateTime {
+	if ms == nil {
+		return MakeNullDateTime()
+	}
+	return ParquetTimestampMillisToDateTime(*ms).Nullable()
+}
+
+// ParquetTimestampMicrosToNullDateTime формирует объект типа NullDateTime
+// на основе значения необязательного столбца Parquet TIMESTAMP(MICROS),
+// nil преобразуется в значение NULL
+func ParquetTimestampMicrosToNullDateTime(us *int64) NullDateTime {
+	if us == nil {
+		return MakeNullDateTime()
+	}
+	return ParquetTimestampMicrosToDateTime(*us).Nullable()
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParquetDate(t *testing.T) {
+	tests := []struct {
+		date string
+		days int32
+	}{
+		{"1970-01-01", 0},
+		{"1969-12-31", -1},
+		{"2017-07-14", 17361},
+		{"0001-01-01", -719162},
+	}
+	for _, test := range tests {
+		d, err := StringToDate(test.date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if days := d.ParquetDate(); days != test.days {
+			t.Fatalf("%s: ожидалось %d, получено %d", test.date, test.days, days)
+		}
+		d2 := ParquetDateToDate(test.days)
+		if d2.String() != test.date || d2.Location() != defaultLocation || d2.Layout != DateLayout {
+			t.Fatalf("Ожидалось %s, получено %s", test.date, d2)
+		}
+	}
+
+	// дата берётся в часовом поясе объекта
+	d := Date{Time: time.Date(2017, 7, 14, 0, 0, 0, 0, time.FixedZone("UTC+5", 5*3600))}
+	if days := d.ParquetDate(); days != 17361 {
+		t.Fatalf("Ожидалось 17361, получено %d", days)
+	}
+
+	if days := MakeNullDate().ParquetDate(); days != nil {
+		t.Fatalf("Ожидалось nil, получено %d", *days)
+	}
+	if days := d.Nullable().ParquetDate(); days == nil || *days != 17361 {
+		t.Fatalf("Ожидалось 17361, получено %v", days)
+	}
+	if nd := ParquetDateToNullDate(nil); nd.Valid {
+		t.Fatalf("Ожидалось значение NULL, получено %s", nd.Date)
+	}
+	days := int32(17361)
+	if nd := ParquetDateToNullDate(&days); !nd.Valid || nd.String() != "2017-07-14" {
+		t.Fatalf("Ожидалось 2017-07-14, получено %v", nd)
+	}
+}
+
+func TestParquetTimestamp(t *testing.T) {
+	tz := time.FixedZone("UTC+5", 5*3600)
+	dt := DateTime{Time: time.Date(2017, 7, 14, 20, 4, 5, 123456789, tz)}
+	if ms := dt.ParquetTimestampMillis(); ms != 1500044645123 {
+		t.Fatalf("Ожидалось 1500044645123, получено %d", ms)
+	}
+	if us := dt.ParquetTimestampMicros(); us != 1500044645123456 {
+		t.Fatalf("Ожидалось 1500044645123456, получено %d", us)
+	}
+
+	dt2 := ParquetTimestampMillisToDateTime(1500044645123)
+	if !dt2.Time.Equal(dt.Truncate(time.Millisecond)) || dt2.Location() != defaultLocation || dt2.Layout != DateTimeLayout {
+		t.Fatalf("Ожидалось %v, получено %v", dt.Truncate(time.Millisecond), dt2.Time)
+	}
+	dt2 = ParquetTimestampMicrosToDateTime(1500044645123456)
+	if !dt2.Time.Equal(dt.Truncate(time.Microsecond)) || dt2.Location() != defaultLocation || dt2.Layout != DateTimeLayout {
+		t.Fatalf("Ожидалось %v, получено %v", dt.Truncate(time.Microsecond), dt2.Time)
+	}
+	if dt2 := ParquetTimestampMillisToDateTime(-1); !dt2.Time.Equal(time.Date(1969, 12, 31, 23, 59, 59, 999000000, time.UTC)) {
+		t.Fatalf("Ожидалось 1969-12-31T23:59:59.999Z, получено %v", dt2.Time)
+	}
+
+	if ms := MakeNullDateTime().ParquetTimestampMillis(); ms != nil {
+		t.Fatalf("Ожидалось nil, получено %d", *ms)
+	}
+	if us := MakeNullDateTime().ParquetTimestampMicros(); us != nil {
+		t.Fatalf("Ожидалось nil, получено %d", *us)
+	}
+	if ms := dt.Nullable().ParquetTimestampMillis(); ms == nil || *ms != 1500044645123 {
+		t.Fatalf("Ожидалось 1500044645123, получено %v", ms)
+	}
+	if us := dt.Nullable().ParquetTimestampMicros(); us == nil || *us != 1500044645123456 {
+		t.Fatalf("Ожидалось 1500044645123456, получено %v", us)
+	}
+	if nd := ParquetTimestampMillisToNullDateTime(nil); nd.Valid {
+		t.Fatalf("Ожидалось значение NULL, получено %s", nd.DateTime)
+	}
+	if nd := ParquetTimestampMicrosToNullDateTime(nil); nd.Valid {
+		t.Fatalf("Ожидалось значение NULL, получено %s", nd.DateTime)
+	}
+	ms, us := int64(1500044645123), int64(1500044645123456)
+	if nd := ParquetTimestampMillisToNullDateTime(&ms); !nd.Valid || nd.ParquetTimestampMillis() == nil || *nd.ParquetTimestampMillis() != ms {
+		t.Fatalf("Ожидалось %d, получено %v", ms, nd)
+	}
+	if nd := ParquetTimestampMicrosToNullDateTime(&us); !nd.Valid || *nd.ParquetTimestampMicros() != us {
+		t.Fatalf("Ожидалось %d, получено %v", us, nd)
+	}
+}
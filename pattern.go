@@ -0,0 +1,276 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// strftimeDirectives хранит соответствие директив strftime элементам шаблонов Go
+var strftimeDirectives = map[string]string{
+	"Y":  "2006",
+	"y":  "06",
+	"m":  "01",
+	"-m": "1",
+	"d":  "02",
+	"-d": "2",
+	"e":  "_2",
+	"j":  "002",
+	"H":  "15",
+	"I":  "03",
+	"-I": "3",
+	"M":  "04",
+	"-M": "4",
+	"S":  "05",
+	"-S": "5",
+	"p":  "PM",
+	"b":  "Jan",
+	"h":  "Jan",
+	"B":  "January",
+	"a":  "Mon",
+	"A":  "Monday",
+	"Z":  "MST",
+	"z":  "-0700",
+	"F":  "2006-01-02",
+	"T":  "15:04:05",
+	"D":  "01/02/06",
+	"R":  "15:04",
+	"%":  "%",
+	"n":  "\n",
+	"t":  "\t",
+}
+
+// icuFields хранит соответствие полей шаблонов ICU (java.text.SimpleDateFormat,
+// DateTimeFormatter) элементам шаблонов Go. Ключ - буква поля и количество её повторов
+var icuFields = map[string]string{
+	"y1": "2006", "y2": "06", "y3": "2006", "y4": "2006",
+	"u1": "2006", "u2": "06", "u4": "2006",
+	"M1": "1", "M2": "01", "M3": "Jan", "M4": "January",
+	"L1": "1", "L2": "01", "L3": "Jan", "L4": "January",
+	"d1": "2", "d2": "02",
+	"D1": "002", "D2": "002", "D3": "002",
+	"E1": "Mon", "E2": "Mon", "E3": "Mon", "E4": "Monday",
+	"a1": "PM",
+	"H1": "15", "H2": "15",
+	"h1": "3", "h2": "03",
+	"m1": "4", "m2": "04",
+	"s1": "5", "s2": "05",
+	"z1": "MST", "z2": "MST", "z3": "MST",
+	"Z1": "-0700", "Z2": "-0700", "Z3": "-0700", "Z5": "Z07:00",
+	"X1": "Z07", "X2": "Z0700", "X3": "Z07:00",
+	"x1": "-07", "x2": "-0700", "x3": "-07:00",
+}
+
+// patternCheckTimes используются для проверки того, что литералы шаблона
+// не содержат элементов шаблонов Go. Все их поля отличаются от эталонного времени Go
+var patternCheckTimes = []time.Time{
+	time.Date(1999, time.November, 28, 9, 37, 48, 123456789, time.FixedZone("XYZ", 3*3600)),
+	time.Date(2010, time.August, 9, 21, 18, 29, 987654321, time.FixedZone("ABC", -5*3600-1800)),
+}
+
+// StrftimeToLayout преобразует шаблон strftime, например "%d.%m.%Y %H:%M",
+// в шаблон Go, пригодный для свойства Layout. Поддерживаются директивы
+// %Y %y %m %d %e %j %H %I %M %S %f %p %b %h %B %a %A %Z %z %F %T %D %R %% %n %t
+// и флаг "-" для %m, %d, %I, %M, %S. Для остальных директив возвращается ошибка.
+func StrftimeToLayout(pattern string) (string, error) {
+	var pieces []string
+	literal := ""
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '%' {
+			literal += pattern[i : i+1]
+			continue
+		}
+		directive := ""
+		if i+1 < len(pattern) {
+			directive = pattern[i+1 : i+2]
+			if directive == "-" && i+2 < len(pattern) {
+				directive = pattern[i+1 : i+3]
+			}
+		}
+		i += len(directive)
+
+		if directive == "f" {
+			if !strings.HasSuffix(literal, ".") && !strings.HasSuffix(literal, ",") {
+				return "", fmt.Errorf("Директива %%f в шаблоне %q должна следовать за точкой или запятой", pattern)
+			}
+			pieces = append(pieces, literal[:len(literal)-1], literal[len(literal)-1:]+"000000")
+			literal = ""
+			continue
+		}
+		element, ok := strftimeDirectives[directive]
+		if !ok {
+			return "", fmt.Errorf("Неподдерживаемая директива strftime %%%s в шаблоне %q", directive, pattern)
+		}
+		if directive == "%" || directive == "n" || directive == "t" {
+			literal += element
+			continue
+		}
+		pieces = append(pieces, literal, element)
+		literal = ""
+	}
+	pieces = append(pieces, literal)
+	return joinLayoutPieces(pattern, pieces)
+}
+
+// ICUToLayout преобразует шаблон ICU (Java), например "dd.MM.yyyy HH:mm",
+// в шаблон Go, пригодный для свойства Layout. Текст в одинарных кавычках
+// выводится как есть, две одинарные кавычки подряд обозначают одну. Для неподдерживаемых
+// полей возвращается ошибка. Поля H и HH всегда выводятся двумя цифрами.
+func ICUToLayout(pattern string) (string, error) {
+	var pieces []string
+	literal := ""
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		switch {
+		case c == '\'':
+			if i+1 < len(pattern) && pattern[i+1] == '\'' {
+				literal += "'"
+				i += 2
+				continue
+			}
+			closed := false
+			for i++; i < len(pattern) && !closed; i++ {
+				switch {
+				case pattern[i] != '\'':
+					literal += pattern[i : i+1]
+				case i+1 < len(pattern) && pattern[i+1] == '\'':
+					literal += "'"
+					i++
+				default:
+					closed = true
+				}
+			}
+			if !closed {
+				return "", fmt.Errorf("Незакрытая кавычка в шаблоне %q", pattern)
+			}
+		case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+			n := 1
+			for i+n < len(pattern) && pattern[i+n] == c {
+				n++
+			}
+			field := pattern[i : i+n]
+			i += n
+			if c == 'S' {
+				if !strings.HasSuffix(literal, ".") && !strings.HasSuffix(literal, ",") {
+					return "", fmt.Errorf("Поле %s в шаблоне %q должно следовать за точкой или запятой", field, pattern)
+				}
+				if n > 9 {
+					return "", fmt.Errorf("Поле %s в шаблоне %q длиннее 9 знаков", field, pattern)
+				}
+				pieces = append(pieces, literal[:len(literal)-1], literal[len(literal)-1:]+strings.Repeat("0", n))
+				literal = ""
+				continue
+			}
+			element, ok := icuFields[fmt.Sprintf("%c%d", c, n)]
+			if !ok {
+				return "", fmt.Errorf("Неподдерживаемое поле ICU %s в шаблоне %q", field, pattern)
+			}
+			pieces = append(pieces, literal, element)
+			literal = ""
+		default:
+			literal += pattern[i : i+1]
+			i++
+		}
+	}
+	pieces = append(pieces, literal)
+	return joinLayoutPieces(pattern, pieces)
+}
+
+// joinLayoutPieces объединяет чередующиеся литералы и элементы шаблона Go в один шаблон,
+// проверяя, что литералы и стыки между частями не порождают лишних элементов
+func joinLayoutPieces(pattern string, pieces []string) (string, error) {
+	layout := strings.Join(pieces, "")
+	for _, t := range patternCheckTimes {
+		expected := ""
+		for i, piece := range pieces {
+			if i%2 == 0 {
+				if t.Format(piece) != piece {
+					return "", fmt.Errorf("Текст %q в шаблоне %q совпадает с элементом шаблона Go", piece, pattern)
+				}
+				expected += piece
+				continue
+			}
+			expected += t.Format(piece)
+		}
+		if t.Format(layout) != expected {
+			return "", fmt.Errorf("Шаблон %q не может быть однозначно преобразован в шаблон Go", pattern)
+		}
+	}
+	return layout, nil
+}
+
+// FormatStrftime преобразует время t в строку согласно шаблону strftime
+func FormatStrftime(t time.Time, pattern string) (string, error) {
+	layout, err := StrftimeToLayout(pattern)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(layout), nil
+}
+
+// ParseStrftime разбирает строку s согласно шаблону strftime в часовом поясе по умолчанию
+func ParseStrftime(pattern, s string) (time.Time, error) {
+	layout, err := StrftimeToLayout(pattern)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.ParseInLocation(layout, s, defaultLocation)
+}
+
+// FormatICU преобразует время t в строку согласно шаблону ICU
+func FormatICU(t time.Time, pattern string) (string, error) {
+	layout, err := ICUToLayout(pattern)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(layout), nil
+}
+
+// ParseICU разбирает строку s согласно шаблону ICU в часовом поясе по умолчанию
+func ParseICU(pattern, s string) (time.Time, error) {
+	layout, err := ICUToLayout(pattern)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.ParseInLocation(layout, s, defaultLocation)
+}
+
+// SetStrftimeLayout устанавливает Layout в объекте DateTime по шаблону strftime
+func (d *DateTime) SetStrftimeLayout(pattern string) error {
+	layout, err := StrftimeToLayout(pattern)
+	if err != nil {
+		return err
+	}
+	d.setLayout(layout)
+	return nil
+}
+
+// SetICULayout устанавливает Layout в объекте DateTime по шаблону ICU
+func (d *DateTime) SetICULayout(pattern string) error {
+	layout, err := ICUToLayout(pattern)
+	if err != nil {
+		return err
+	}
+	d.setLayout(layout)
+	return nil
+}
+
+// SetStrftimeLayout устанавливает Layout в объекте Date по шаблону strftime
+func (d *Date) SetStrftimeLayout(pattern string) error {
+	layout, err := StrftimeToLayout(pattern)
+	if err != nil {
+		return err
+	}
+	d.setLayout(layout)
+	return nil
+}
+
+// SetICULayout устанавливает Layout в объекте Date по шаблону ICU
+func (d *Date) SetICULayout(pattern string) error {
+	layout, err := ICUToLayout(pattern)
+	if err != nil {
+		return err
+	}
+	d.setLayout(layout)
+	return nil
+}
@@ -0,0 +1,171 @@
+package types
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func TestStrftimeToLayout(t *testing.T) {
+	tests := map[string]string{
+		"%d.%m.%Y":            "02.01.2006",
+		"%Y-%m-%d %H:%M:%S":   "2006-01-02 15:04:05",
+		"%F %T":               "2006-01-02 15:04:05",
+		"%-d/%-m/%y %I:%M %p": "2/1/06 03:04 PM",
+		"%A, %e %B":           "Monday, _2 January",
+		"%H:%M:%S.%f %z":      "15:04:05.000000 -0700",
+		"%% к %j дню":         "% к 002 дню",
+		"%d.%m.%Y г.":         "02.01.2006 г.",
+	}
+	for pattern, expected := range tests {
+		layout, err := StrftimeToLayout(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if layout != expected {
+			t.Fatalf("%q: ожидалось %q, получено %q", pattern, expected, layout)
+		}
+	}
+
+	for _, pattern := range []string{"%U", "%d.%m.%Y %", "%-", "%f", "Q1 %Y", "%m0%d", "%Y Mon"} {
+		if _, err := StrftimeToLayout(pattern); err == nil {
+			t.Fatalf("%q: ожидалась ошибка", pattern)
+		}
+	}
+}
+
+func TestICUToLayout(t *testing.T) {
+	tests := map[string]string{
+		"dd.MM.yyyy HH:mm":             "02.01.2006 15:04",
+		"d MMM yy":                     "2 Jan 06",
+		"EEEE, MMMM d, yyyy h:mm a":    "Monday, January 2, 2006 3:04 PM",
+		"yyyy-MM-dd'T'HH:mm:ss.SSSXXX": "2006-01-02T15:04:05.000Z07:00",
+		"HH 'o''clock'":                "15 o'clock",
+		"dd.MM.yyyy 'г.'":              "02.01.2006 г.",
+	}
+	for pattern, expected := range tests {
+		layout, err := ICUToLayout(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if layout != expected {
+			t.Fatalf("%q: ожидалось %q, получено %q", pattern, expected, layout)
+		}
+	}
+
+	for _, pattern := range []string{"QQ yyyy", "dd.MM.yyyy 'г", "SSS", "w", "'Q1' yyyy"} {
+		if _, err := ICUToLayout(pattern); err == nil {
+			t.Fatalf("%q: ожидалась ошибка", pattern)
+		}
+	}
+}
+
+func TestFormatParsePatterns(t *testing.T) {
+	tm := time.Date(2017, time.July, 4, 9, 5, 0, 0, defaultLocation)
+
+	s, err := FormatStrftime(tm, "%d.%m.%Y %H:%M")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "04.07.2017 09:05" {
+		t.Fatalf("Ожидалось 04.07.2017 09:05, получено %s", s)
+	}
+	parsed, err := ParseStrftime("%d.%m.%Y %H:%M", s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.Equal(tm) {
+		t.Fatalf("Ожидалось %v, получено %v", tm, parsed)
+	}
+
+	s, err = FormatICU(tm, "d.M.yy h:mm a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "4.7.17 9:05 AM" {
+		t.Fatalf("Ожидалось 4.7.17 9:05 AM, получено %s", s)
+	}
+	parsed, err = ParseICU("d.M.yy h:mm a", s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.Equal(tm) {
+		t.Fatalf("Ожидалось %v, получено %v", tm, parsed)
+	}
+
+	if _, err := FormatStrftime(tm, "%Q"); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+	if _, err := ParseICU("dd.MM.yyyy", "2017-07-04"); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+}
+
+func TestSetPatternLayout(t *testing.T) {
+	dt := DateTime{}
+	if err := dt.SetICULayout("dd.MM.yyyy HH:mm"); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`"14.07.2017 15:04"`), &dt); err != nil {
+		t.Fatal(err)
+	}
+	if s := dt.String(); s != "14.07.2017 15:04" {
+		t.Fatalf("Ожидалось 14.07.2017 15:04, получено %s", s)
+	}
+
+	nd := NullDate{}
+	if err := nd.SetStrftimeLayout("%d/%m/%Y"); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`"14/07/2017"`), &nd); err != nil {
+		t.Fatal(err)
+	}
+	if !nd.Valid || nd.Time.Format(DateLayout) != "2017-07-14" {
+		t.Fatalf("Ожидалось 2017-07-14, получено %v", nd)
+	}
+
+	if err := nd.SetICULayout("QQ"); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+}
+
+func TestNullLayoutUnmarshal(t *testing.T) {
+	var doc struct {
+		XMLName xml.Name     `xml:"doc"`
+		At      NullDateTime `json:"at" xml:"at"`
+		On      NullDate     `json:"on" xml:"on"`
+	}
+	reset := func() {
+		doc.At = NullDateTime{DateTime: DateTime{Layout: "02.01.2006 15:04"}}
+		doc.On = NullDate{Date: Date{Layout: "02/01/2006"}}
+	}
+
+	reset()
+	if err := json.Unmarshal([]byte(`{"at":"14.07.2017 15:04","on":"14/07/2017"}`), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if !doc.At.Valid || doc.At.Time.Format(DateTimeLayout) != "2017-07-14 15:04:00" {
+		t.Fatalf("Ожидалось 2017-07-14 15:04:00, получено %v", doc.At)
+	}
+	if !doc.On.Valid || doc.On.Time.Format(DateLayout) != "2017-07-14" {
+		t.Fatalf("Ожидалось 2017-07-14, получено %v", doc.On)
+	}
+	if doc.At.Layout != "02.01.2006 15:04" || doc.On.Layout != "02/01/2006" {
+		t.Fatalf("Ожидалось сохранение шаблонов, получено %s и %s", doc.At.Layout, doc.On.Layout)
+	}
+
+	reset()
+	if err := xml.Unmarshal([]byte(`<doc><at>15.08.2018 10:30</at><on>15/08/2018</on></doc>`), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if !doc.At.Valid || doc.At.Time.Format(DateTimeLayout) != "2018-08-15 10:30:00" {
+		t.Fatalf("Ожидалось 2018-08-15 10:30:00, получено %v", doc.At)
+	}
+	if !doc.On.Valid || doc.On.Time.Format(DateLayout) != "2018-08-15" {
+		t.Fatalf("Ожидалось 2018-08-15, получено %v", doc.On)
+	}
+	if doc.At.Layout != "02.01.2006 15:04" || doc.On.Layout != "02/01/2006" {
+		t.Fatalf("Ожидалось сохранение шаблонов, получено %s и %s", doc.At.Layout, doc.On.Layout)
+	}
+}
@@ -0,0 +1,395 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period хранит календарный период: годы, месяцы, дни и длительность.
+// Годы, месяцы и дни прибавляются по календарю, длительность - как time.Duration.
+type Period struct {
+	Years    int
+	Months   int
+	Days     int
+	Duration time.Duration
+}
+
+// NewPeriod создаёт объект Period с заданными годами, месяцами, днями и длительностью
+func NewPeriod(years, months, days int, duration time.Duration) Period {
+	return Period{Years: years, Months: months, Days: days, Duration: duration}
+}
+
+// ParsePeriod формирует объект Period на основе строки s в формате ISO 8601,
+// например "P1Y2M10DT2H30M", "P2W", "PT36H", "PT0.5S" или "-P1M15D"
+func ParsePeriod(s string) (Period, error) {
+	var p Period
+	rest := s
+	negative := false
+	if strings.HasPrefix(rest, "-") {
+		negative = true
+		rest = rest[1:]
+	} else if strings.HasPrefix(rest, "+") {
+		rest = rest[1:]
+	}
+	if !strings.HasPrefix(rest, "P") || len(rest) == 1 {
+		return Period{}, fmt.Errorf("Неверный формат периода: %q", s)
+	}
+	rest = rest[1:]
+
+	inTime := false
+	for rest != "" {
+		if rest[0] == 'T' {
+			if inTime || len(rest) == 1 {
+				return Period{}, fmt.Errorf("Неверный формат периода: %q", s)
+			}
+			inTime = true
+			rest = rest[1:]
+			continue
+		}
+		i := strings.IndexFunc(rest, func(r rune) bool {
+			return r != '-' && r != '+' && r != '.' && r != ',' && (r < '0' || r > '9')
+		})
+		if i <= 0 {
+			return Period{}, fmt.Errorf("Неверный формат периода: %q", s)
+		}
+		number, designator := strings.Replace(rest[:i], ",", ".", 1), rest[i]
+		rest = rest[i+1:]
+
+		if inTime && designator == 'S' {
+			seconds, err := time.ParseDuration(number + "s")
+			if err != nil {
+				return Period{}, fmt.Errorf("Неверный формат периода: %q", s)
+			}
+			p.Duration += seconds
+			continue
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return Period{}, fmt.Errorf("Неверный формат периода: %q", s)
+		}
+		switch {
+		case !inTime && designator == 'Y':
+			p.Years += n
+		case !inTime && designator == 'M':
+			p.Months += n
+		case !inTime && designator == 'W':
+			p.Days += 7 * n
+		case !inTime && designator == 'D':
+			p.Days += n
+		case inTime && designator == 'H':
+			p.Duration += time.Duration(n) * time.Hour
+		case inTime && designator == 'M':
+			p.Duration += time.Duration(n) * time.Minute
+		default:
+			return Period{}, fmt.Errorf("Неверный формат периода: %q", s)
+		}
+	}
+	if negative {
+		p = p.Negate()
+	}
+	return p, nil
+}
+
+// String преобразует период p в строку в формате ISO 8601, например "P1Y2M10DT2H30M".
+// Если все составляющие периода отрицательны, знак выносится вперёд: "-P1M15D"
+func (p Period) String() string {
+	if p.IsZero() {
+		return "P0D"
+	}
+	sign := ""
+	if p.Years <= 0 && p.Months <= 0 && p.Days <= 0 && p.Duration <= 0 {
+		sign = "-"
+		p = p.Negate()
+	}
+
+	res := sign + "P"
+	if p.Years != 0 {
+		res += strconv.Itoa(p.Years) + "Y"
+	}
+	if p.Months != 0 {
+		res += strconv.Itoa(p.Months) + "M"
+	}
+	if p.Days != 0 {
+		res += strconv.Itoa(p.Days) + "D"
+	}
+	if p.Duration != 0 {
+		res += "T"
+		hours := p.Duration / time.Hour
+		minutes := p.Duration % time.Hour / time.Minute
+		seconds := p.Duration % time.Minute
+		if hours != 0 {
+			res += strconv.FormatInt(int64(hours), 10) + "H"
+		}
+		if minutes != 0 {
+			res += strconv.FormatInt(int64(minutes), 10) + "M"
+		}
+		if seconds != 0 {
+			res += strconv.FormatFloat(seconds.Seconds(), 'f', -1, 64) + "S"
+		}
+	}
+	return res
+}
+
+// IsZero возвращает true если все составляющие периода p равны нулю
+func (p Period) IsZero() bool {
+	return p.Years == 0 && p.Months == 0 && p.Days == 0 && p.Duration == 0
+}
+
+// Negate возвращает период, противоположный p
+func (p Period) Negate() Period {
+	return Period{Years: -p.Years, Months: -p.Months, Days: -p.Days, Duration: -p.Duration}
+}
+
+// Normalize переносит полные годы из месяцев в годы, например P1Y14M -> P2Y2M.
+// Дни и длительность не переносятся, так как длина месяца и дня непостоянна.
+func (p Period) Normalize() Period {
+	months := p.Years*12 + p.Months
+	p.Years = months / 12
+	p.Months = months % 12
+	return p
+}
+
+// Equal возвращает true если периоды p и p1 равны после нормализации, иначе false
+func (p Period) Equal(p1 Period) bool {
+	return p.Normalize() == p1.Normalize()
+}
+
+// Compare сравнивает периоды p и p1, отложенные от даты-времени from, и возвращает:
+//
+//	-1 если p <  p1
+//	 0 если p == p1
+//	+1 если p >  p1
+func (p Period) Compare(p1 Period, from DateTime) int {
+	t, t1 := from.AddPeriod(p), from.AddPeriod(p1)
+	switch {
+	case t.Before(t1):
+		return -1
+	case t.After(t1):
+		return 1
+	}
+	return 0
+}
+
+// addPeriod прибавляет к t период p. Месяцы прибавляются так же, как в AddMonths
+func addPeriod(t time.Time, p Period) time.Time {
+	return addMonths(t, p.Years*12+p.Months).AddDate(0, 0, p.Days).Add(p.Duration)
+}
+
+// AddPeriod прибавляет к дате-времени d период p. Годы и месяцы прибавляются
+// так же, как в AddMonths, затем прибавляются дни и длительность
+func (d DateTime) AddPeriod(p Period) DateTime {
+	d.Time = addPeriod(d.Time, p)
+	return d
+}
+
+// AddPeriod прибавляет к дате d период p и отбрасывает получившееся время.
+// Годы и месяцы прибавляются так же, как в AddMonths
+func (d Date) AddPeriod(p Period) Date {
+	t := addPeriod(d.Time, p)
+	d.Time = setDate(t, t.Year(), t.Month(), t.Day(), 0, 0, 0)
+	return d
+}
+
+// PeriodBetween возвращает период p такой, что a.AddPeriod(p) равно b.
+// Если b раньше a, возвращается отрицательный период, отсчитанный от a назад:
+// сначала месяцы, затем дни и длительность
+func PeriodBetween(a, b DateTime) Period {
+	sign := 1
+	if b.Before(a) {
+		sign = -1
+	}
+	// passed проверяет, прошло ли t дату-время b при движении от a к b
+	passed := func(t time.Time) bool {
+		if sign > 0 {
+			return t.After(b.Time)
+		}
+		return t.Before(b.Time)
+	}
+
+	months := (b.Year()-a.Year())*12 + int(b.Month()) - int(a.Month())
+	if passed(addMonths(a.Time, months)) {
+		months -= sign
+	}
+	t := addMonths(a.Time, months)
+	days := int(b.Sub(t).Hours() / 24)
+	for days != 0 && passed(t.AddDate(0, 0, days)) {
+		days -= sign
+	}
+	for !passed(t.AddDate(0, 0, days+sign)) {
+		days += sign
+	}
+	p := Period{Days: days, Duration: b.Sub(t.AddDate(0, 0, days))}
+	p.Years, p.Months = months/12, months%12
+	return p
+}
+
+// UnmarshalJSON - реализует интерфейс json.Unmarshaler для объекта Period
+func (p *Period) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParsePeriod(s)
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// MarshalJSON - реализует интерфейс json.Marshaler для объекта Period
+func (p Period) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(p.String())), nil
+}
+
+// UnmarshalXML реализует интерфейс xml.Unmarshaler для объекта Period
+func (p *Period) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	var content string
+	if err := decoder.DecodeElement(&content, &start); err != nil {
+		return err
+	}
+	parsed, err := ParsePeriod(content)
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// MarshalXML реализует интерфейс xml.Marshaler для объекта Period
+func (p Period) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	return encoder.EncodeElement(p.String(), start)
+}
+
+// Scan преобразует значение интервала в БД к типу Period. Поддерживаются
+// форматы вывода interval в Postgres: "postgres" ("1 year 2 mons 3 days 04:05:06")
+// и "iso_8601" ("P1Y2M3DT4H5M6S")
+// Реализует интерфейс sql.Scanner
+func (p *Period) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		*p = Period{}
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("Ошибка преобразования значения %v к типу Period", value)
+	}
+
+	var parsed Period
+	var err error
+	if strings.HasPrefix(s, "P") || strings.HasPrefix(s, "-P") {
+		parsed, err = ParsePeriod(s)
+	} else {
+		parsed, err = parsePostgresInterval(s)
+	}
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// Value преобразует значение типа Period к значению interval в БД
+// в формате вывода Postgres "postgres"
+// Реализует интерфейс driver.Valuer
+func (p Period) Value() (driver.Value, error) {
+	var parts []string
+	if p.Years != 0 {
+		parts = append(parts, fmt.Sprintf("%d years", p.Years))
+	}
+	if p.Months != 0 {
+		parts = append(parts, fmt.Sprintf("%d mons", p.Months))
+	}
+	if p.Days != 0 {
+		parts = append(parts, fmt.Sprintf("%d days", p.Days))
+	}
+	if p.Duration != 0 || len(parts) == 0 {
+		d := p.Duration
+		sign := ""
+		if d < 0 {
+			sign = "-"
+			d = -d
+		}
+		s := fmt.Sprintf("%s%02d:%02d:%02d", sign, d/time.Hour, d%time.Hour/time.Minute, d%time.Minute/time.Second)
+		if frac := d % time.Second; frac != 0 {
+			s += strings.TrimRight(fmt.Sprintf(".%09d", frac), "0")
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// parsePostgresInterval разбирает интервал в формате вывода Postgres "postgres"
+func parsePostgresInterval(s string) (Period, error) {
+	var p Period
+	fields := strings.Fields(s)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if strings.Contains(field, ":") {
+			d, err := parsePostgresTime(field)
+			if err != nil {
+				return Period{}, fmt.Errorf("Неверный формат интервала: %q", s)
+			}
+			p.Duration += d
+			continue
+		}
+		if i+1 >= len(fields) {
+			return Period{}, fmt.Errorf("Неверный формат интервала: %q", s)
+		}
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return Period{}, fmt.Errorf("Неверный формат интервала: %q", s)
+		}
+		i++
+		switch unit := fields[i]; {
+		case strings.HasPrefix(unit, "year"):
+			p.Years += n
+		case strings.HasPrefix(unit, "mon"):
+			p.Months += n
+		case strings.HasPrefix(unit, "day"):
+			p.Days += n
+		default:
+			return Period{}, fmt.Errorf("Неверный формат интервала: %q", s)
+		}
+	}
+	return p, nil
+}
+
+// parsePostgresTime разбирает время интервала вида "[+-]HH:MM:SS[.ffffff]"
+func parsePostgresTime(s string) (time.Duration, error) {
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimLeft(s, "+-")
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("неверный формат времени %q", s)
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, err
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, err
+	}
+	d := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute
+	if len(parts) == 3 {
+		seconds, err := time.ParseDuration(parts[2] + "s")
+		if err != nil {
+			return 0, err
+		}
+		d += seconds
+	}
+	if negative {
+		d = -d
+	}
+	return d, nil
+}
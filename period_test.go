@@ -0,0 +1,190 @@
+package types
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected Period
+		str      string
+	}{
+		{"P1M15D", NewPeriod(0, 1, 15, 0), "P1M15D"},
+		{"PT36H", NewPeriod(0, 0, 0, 36*time.Hour), "PT36H"},
+		{"P1Y2M10DT2H30M", NewPeriod(1, 2, 10, 2*time.Hour+30*time.Minute), "P1Y2M10DT2H30M"},
+		{"P2W", NewPeriod(0, 0, 14, 0), "P14D"},
+		{"PT0,5S", NewPeriod(0, 0, 0, 500*time.Millisecond), "PT0.5S"},
+		{"-P1M15D", NewPeriod(0, -1, -15, 0), "-P1M15D"},
+		{"P1M-1D", NewPeriod(0, 1, -1, 0), "P1M-1D"},
+		{"P0D", Period{}, "P0D"},
+	}
+	for _, test := range tests {
+		p, err := ParsePeriod(test.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p != test.expected {
+			t.Fatalf("%s: ожидалось %#v, получено %#v", test.s, test.expected, p)
+		}
+		if s := p.String(); s != test.str {
+			t.Fatalf("Ожидалось %s, получено %s", test.str, s)
+		}
+	}
+
+	for _, s := range []string{"", "P", "1D", "PT", "P1H", "PT1D", "P1.5D", "P1DT", "PxD"} {
+		if _, err := ParsePeriod(s); err == nil {
+			t.Fatalf("%q: ожидалась ошибка", s)
+		}
+	}
+}
+
+func TestPeriodNormalizeEqualCompare(t *testing.T) {
+	p := NewPeriod(1, 14, 3, 0)
+	if n := p.Normalize(); n != NewPeriod(2, 2, 3, 0) {
+		t.Fatalf("Ожидалось P2Y2M3D, получено %s", n)
+	}
+	if !p.Equal(NewPeriod(0, 26, 3, 0)) {
+		t.Fatal("Периоды должны быть равны")
+	}
+	if p.Equal(p.Negate()) {
+		t.Fatal("Периоды не должны быть равны")
+	}
+
+	from, err := StringToDateTime("2017-02-01 00:00:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	month, days := NewPeriod(0, 1, 0, 0), NewPeriod(0, 0, 30, 0)
+	if c := month.Compare(days, from); c != -1 {
+		t.Fatalf("В феврале месяц должен быть меньше 30 дней, получено %d", c)
+	}
+	if c := month.Compare(NewPeriod(0, 0, 28, 0), from); c != 0 {
+		t.Fatalf("В феврале месяц должен быть равен 28 дням, получено %d", c)
+	}
+	if c := month.Compare(NewPeriod(0, 0, 0, 24*time.Hour), from); c != 1 {
+		t.Fatalf("Месяц должен быть больше суток, получено %d", c)
+	}
+}
+
+func TestAddPeriodAndPeriodBetween(t *testing.T) {
+	tests := []struct {
+		from, to string
+		period   string
+	}{
+		{"2017-01-31 10:00:00", "2017-02-28 10:00:00", "P1M"},
+		{"2017-01-31 10:00:00", "2017-03-01 12:30:00", "P1M1DT2H30M"},
+		{"2016-02-29 00:00:00", "2017-02-28 00:00:00", "P1Y"},
+		{"2017-07-14 23:00:00", "2017-07-15 01:00:00", "PT2H"},
+		{"2017-07-15 01:00:00", "2017-07-14 23:00:00", "-PT2H"},
+		{"2017-03-31 10:00:00", "2017-02-28 10:00:00", "-P1M"},
+		{"2017-03-31 10:00:00", "2017-02-28 12:00:00", "-P30DT22H"},
+		{"2017-03-01 12:30:00", "2017-01-31 10:00:00", "-P1M1DT2H30M"},
+		{"2017-03-31 00:00:00", "2016-02-29 00:00:00", "-P1Y1M"},
+		{"2017-07-14 12:00:00", "2017-07-14 12:00:00", "P0D"},
+	}
+	for _, test := range tests {
+		from, err := StringToDateTime(test.from)
+		if err != nil {
+			t.Fatal(err)
+		}
+		to, err := StringToDateTime(test.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := PeriodBetween(from, to)
+		if s := p.String(); s != test.period {
+			t.Fatalf("%s - %s: ожидалось %s, получено %s", test.from, test.to, test.period, s)
+		}
+		if s := from.AddPeriod(p).String(); s != test.to {
+			t.Fatalf("%s + %s: ожидалось %s, получено %s", test.from, p, test.to, s)
+		}
+	}
+
+	d, err := StringToDate("2017-01-31")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := d.AddPeriod(NewPeriod(0, 1, 1, 36*time.Hour)).String(); s != "2017-03-02" {
+		t.Fatalf("Ожидалось 2017-03-02, получено %s", s)
+	}
+}
+
+func TestPeriodJSONXML(t *testing.T) {
+	type config struct {
+		Timeout Period
+	}
+	c := config{Timeout: NewPeriod(0, 1, 15, 0)}
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"Timeout":"P1M15D"}`; string(b) != expected {
+		t.Fatalf("Ожидалось %s, получено %s", expected, b)
+	}
+	var fromJSON config
+	if err := json.Unmarshal(b, &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	if fromJSON != c {
+		t.Fatalf("Ожидалось %v, получено %v", c, fromJSON)
+	}
+	if err := json.Unmarshal([]byte(`{"Timeout":"1 day"}`), &fromJSON); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+
+	b, err = xml.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `<config><Timeout>P1M15D</Timeout></config>`; string(b) != expected {
+		t.Fatalf("Ожидалось %s, получено %s", expected, b)
+	}
+	var fromXML config
+	if err := xml.Unmarshal(b, &fromXML); err != nil {
+		t.Fatal(err)
+	}
+	if fromXML != c {
+		t.Fatalf("Ожидалось %v, получено %v", c, fromXML)
+	}
+}
+
+func TestPeriodScanValueForDB(t *testing.T) {
+	tests := []struct {
+		db       string
+		expected Period
+		value    string
+	}{
+		{"1 year 2 mons 3 days 04:05:06", NewPeriod(1, 2, 3, 4*time.Hour+5*time.Minute+6*time.Second), "1 years 2 mons 3 days 04:05:06"},
+		{"-1 days +02:03:00", NewPeriod(0, 0, -1, 2*time.Hour+3*time.Minute), "-1 days 02:03:00"},
+		{"-00:00:01.5", NewPeriod(0, 0, 0, -1500*time.Millisecond), "-00:00:01.5"},
+		{"00:00:00", Period{}, "00:00:00"},
+		{"P1Y2M3DT4H", NewPeriod(1, 2, 3, 4*time.Hour), "1 years 2 mons 3 days 04:00:00"},
+	}
+	for _, test := range tests {
+		var p Period
+		if err := p.Scan([]byte(test.db)); err != nil {
+			t.Fatal(err)
+		}
+		if p != test.expected {
+			t.Fatalf("%s: ожидалось %#v, получено %#v", test.db, test.expected, p)
+		}
+		v, err := p.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != test.value {
+			t.Fatalf("Ожидалось %s, получено %v", test.value, v)
+		}
+	}
+
+	var p Period
+	for _, value := range []interface{}{"1 fortnight", "3", 42} {
+		if err := p.Scan(value); err == nil {
+			t.Fatalf("%v: ожидалась ошибка", value)
+		}
+	}
+}
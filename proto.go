@@ -0,0 +1,204 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mihteh/types/internal/protowire"
+)
+
+// ProtoTimestamp повторяет структуру сообщения google.protobuf.Timestamp:
+// секунды и наносекунды от начала эпохи Unix в UTC.
+// Поля совпадают с полями сгенерированного типа timestamppb.Timestamp,
+// а методы Marshal и Unmarshal работают с двоичным форматом protobuf,
+// поэтому пакет не зависит от библиотек protobuf
+type ProtoTimestamp struct {
+	Seconds int64
+	Nanos   int32
+}
+
+// ProtoDate повторяет структуру сообщения google.type.Date
+type ProtoDate struct {
+	Year  int32
+	Month int32
+	Day   int32
+}
+
+// Границы значений google.protobuf.Timestamp: 0001-01-01T00:00:00Z - 9999-12-31T23:59:59.999999999Z
+const (
+	protoTimestampMinSeconds = -62135596800
+	protoTimestampMaxSeconds = 253402300799
+)
+
+// Validate проверяет, что ts находится в допустимом для google.protobuf.Timestamp диапазоне
+func (ts ProtoTimestamp) Validate() error {
+	if ts.Seconds < protoTimestampMinSeconds || ts.Seconds > protoTimestampMaxSeconds {
+		return fmt.Errorf("Значение Timestamp вне допустимого диапазона: %d секунд", ts.Seconds)
+	}
+	if ts.Nanos < 0 || ts.Nanos >= 1e9 {
+		return fmt.Errorf("Неверное значение Timestamp: %d наносекунд", ts.Nanos)
+	}
+	return nil
+}
+
+// ProtoTimestamp преобразует DateTime в ProtoTimestamp с точностью до наносекунды.
+// Возвращает ошибку, если время вне диапазона годов 1 - 9999 в UTC
+func (d DateTime) ProtoTimestamp() (ProtoTimestamp, error) {
+	ts := ProtoTimestamp{Seconds: d.Unix(), Nanos: int32(d.Nanosecond())}
+	return ts, ts.Validate()
+}
+
+// ProtoTimestampToDateTime формирует объект типа DateTime на основе ts
+// в часовом поясе по умолчанию и с шаблоном DateTimeLayout.
+// В отличие от ToDateTime, наносекунды сохраняются
+func ProtoTimestampToDateTime(ts ProtoTimestamp) (DateTime, error) {
+	if err := ts.Validate(); err != nil {
+		return DateTime{}, err
+	}
+	dt := NewDateTime()
+	dt.setTime(time.Unix(ts.Seconds, int64(ts.Nanos)).In(defaultLocation))
+	return dt, nil
+}
+
+// ProtoTimestamp преобразует NullDateTime в *ProtoTimestamp,
+// значение NULL преобразуется в nil (отсутствующее сообщение)
+func (d NullDateTime) ProtoTimestamp() (*ProtoTimestamp, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	ts, err := d.DateTime.ProtoTimestamp()
+	if err != nil {
+		return nil, err
+	}
+	return &ts, nil
+}
+
+// ProtoTimestampToNullDateTime формирует объект типа NullDateTime на основе ts,
+// nil преобразуется в значение NULL
+func ProtoTimestampToNullDateTime(ts *ProtoTimestamp) (NullDateTime, error) {
+	if ts == nil {
+		return MakeNullDateTime(), nil
+	}
+	d, err := ProtoTimestampToDateTime(*ts)
+	if err != nil {
+		return MakeNullDateTime(), err
+	}
+	return d.Nullable(), nil
+}
+
+// Validate проверяет, что pd содержит полную дату в диапазоне годов 1 - 9999.
+// Частичные даты google.type.Date (без года, месяца или дня) не поддерживаются
+func (pd ProtoDate) Validate() error {
+	if pd.Year < 1 || pd.Year > 9999 {
+		return fmt.Errorf("Неверный год в Date: %d", pd.Year)
+	}
+	if pd.Month < 1 || pd.Month > 12 {
+		return fmt.Errorf("Неверный месяц в Date: %d", pd.Month)
+	}
+	if pd.Day < 1 || int(pd.Day) > daysInMonth(int(pd.Year), time.Month(pd.Month)) {
+		return fmt.Errorf("Неверный день в Date: %d", pd.Day)
+	}
+	return nil
+}
+
+// ProtoDate преобразует Date в ProtoDate, дата берётся в часовом поясе объекта
+func (d Date) ProtoDate() ProtoDate {
+	year, month, day := d.Date()
+	return ProtoDate{Year: int32(year), Month: int32(month), Day: int32(day)}
+}
+
+// ProtoDateToDate формирует объект типа Date на основе pd
+// в часовом поясе по умолчанию и с шаблоном DateLayout
+func ProtoDateToDate(pd ProtoDate) (Date, error) {
+	if err := pd.Validate(); err != nil {
+		return Date{}, err
+	}
+	return ToDate(time.Date(int(pd.Year), time.Month(pd.Month), int(pd.Day), 0, 0, 0, 0, defaultLocation)), nil
+}
+
+// ProtoDate преобразует NullDate в *ProtoDate,
+// значение NULL преобразуется в nil (отсутствующее сообщение)
+func (d NullDate) ProtoDate() *ProtoDate {
+	if !d.Valid {
+		return nil
+	}
+	pd := d.Date.ProtoDate()
+	return &pd
+}
+
+// ProtoDateToNullDate формирует объект типа NullDate на основе pd,
+// nil преобразуется в значение NULL
+func ProtoDateToNullDate(pd *ProtoDate) (NullDate, error) {
+	if pd == nil {
+		return MakeNullDate(), nil
+	}
+	d, err := ProtoDateToDate(*pd)
+	if err != nil {
+		return MakeNullDate(), err
+	}
+	return d.Nullable(), nil
+}
+
+// Marshal кодирует ts в двоичный формат protobuf
+func (ts ProtoTimestamp) Marshal() ([]byte, error) {
+	var b []byte
+	b = protowire.AppendVarint(b, 1, uint64(ts.Seconds))
+	b = protowire.AppendVarint(b, 2, uint64(int64(ts.Nanos)))
+	return b, nil
+}
+
+// Unmarshal декодирует ts из двоичного формата protobuf
+func (ts *ProtoTimestamp) Unmarshal(data []byte) error {
+	*ts = ProtoTimestamp{}
+	return protoRead(data, func(field int, v uint64, _ []byte) {
+		switch field {
+		case 1:
+			ts.Seconds = int64(v)
+		case 2:
+			ts.Nanos = int32(v)
+		}
+	})
+}
+
+// Marshal кодирует pd в двоичный формат protobuf
+func (pd ProtoDate) Marshal() ([]byte, error) {
+	var b []byte
+	b = protowire.AppendVarint(b, 1, uint64(int64(pd.Year)))
+	b = protowire.AppendVarint(b, 2, uint64(int64(pd.Month)))
+	b = protowire.AppendVarint(b, 3, uint64(int64(pd.Day)))
+	return b, nil
+}
+
+// Unmarshal декодирует pd из двоичного формата protobuf
+func (pd *ProtoDate) Unmarshal(data []byte) error {
+	*pd = ProtoDate{}
+	return protoRead(data, func(field int, v uint64, _ []byte) {
+		switch field {
+		case 1:
+			pd.Year = int32(v)
+		case 2:
+			pd.Month = int32(v)
+		case 3:
+			pd.Day = int32(v)
+		}
+	})
+}
+
+// protoRead разбирает сообщение data с помощью protowire.Read
+// и переводит ошибки разбора на русский язык
+func protoRead(data []byte, set func(field int, v uint64, b []byte)) error {
+	err := protowire.Read(data, set)
+	var e *protowire.Error
+	if !errors.As(err, &e) {
+		return err
+	}
+	switch {
+	case e.Field == 0:
+		return errors.New("Неверный формат protobuf: ошибка чтения ключа поля")
+	case e.Unsupported:
+		return fmt.Errorf("Неверный формат protobuf: неподдерживаемый тип %d поля %d", e.WireType, e.Field)
+	default:
+		return fmt.Errorf("Неверный формат protobuf: ошибка чтения поля %d", e.Field)
+	}
+}
@@ -0,0 +1,135 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProtoTimestamp(t *testing.T) {
+	tz := time.FixedZone("UTC+5", 5*3600)
+	dt := DateTime{Time: time.Date(2017, 7, 14, 20, 4, 5, 123456789, tz)}
+	ts, err := dt.ProtoTimestamp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ts.Seconds != 1500044645 || ts.Nanos != 123456789 {
+		t.Fatalf("Ожидалось 1500044645.123456789, получено %d.%d", ts.Seconds, ts.Nanos)
+	}
+
+	data, err := ts.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ts2 ProtoTimestamp
+	if err := ts2.Unmarshal(data); err != nil {
+		t.Fatal(err)
+	}
+	if ts2 != ts {
+		t.Fatalf("Ожидалось %+v, получено %+v", ts, ts2)
+	}
+	dt2, err := ProtoTimestampToDateTime(ts2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dt2.Equal(dt) || dt2.Location() != defaultLocation || dt2.Layout != DateTimeLayout {
+		t.Fatalf("Ожидалось %v, получено %v", dt.Time, dt2.Time)
+	}
+
+	// время до начала эпохи Unix: наносекунды всегда положительные
+	before := DateTime{Time: time.Date(1969, 12, 31, 23, 59, 59, 500000000, time.UTC)}
+	if ts, err := before.ProtoTimestamp(); err != nil || ts.Seconds != -1 || ts.Nanos != 500000000 {
+		t.Fatalf("Ожидалось -1.500000000, получено %+v, %v", ts, err)
+	}
+
+	bounds := []time.Time{
+		time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(9999, 12, 31, 23, 59, 59, 999999999, time.UTC),
+	}
+	for _, b := range bounds {
+		if _, err := (DateTime{Time: b}).ProtoTimestamp(); err != nil {
+			t.Fatalf("%v: %s", b, err)
+		}
+	}
+	outOfRange := []time.Time{bounds[0].Add(-time.Nanosecond), bounds[1].Add(time.Nanosecond)}
+	for _, b := range outOfRange {
+		if _, err := (DateTime{Time: b}).ProtoTimestamp(); err == nil {
+			t.Fatalf("%v: ожидалась ошибка", b)
+		}
+	}
+	for _, ts := range []ProtoTimestamp{{Seconds: protoTimestampMaxSeconds + 1}, {Nanos: -1}, {Nanos: 1e9}} {
+		if _, err := ProtoTimestampToDateTime(ts); err == nil {
+			t.Fatalf("%+v: ожидалась ошибка", ts)
+		}
+	}
+
+	if ts, err := MakeNullDateTime().ProtoTimestamp(); ts != nil || err != nil {
+		t.Fatalf("Ожидалось nil, получено %v, %v", ts, err)
+	}
+	if nd, err := ProtoTimestampToNullDateTime(nil); nd.Valid || err != nil {
+		t.Fatalf("Ожидалось NULL, получено %v, %v", nd, err)
+	}
+	if nd, err := ProtoTimestampToNullDateTime(&ts); !nd.Valid || err != nil || !nd.Equal(dt) {
+		t.Fatalf("Ожидалось %v, получено %v, %v", dt, nd, err)
+	}
+}
+
+func TestProtoDate(t *testing.T) {
+	d, err := StringToDate("2016-02-29")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pd := d.ProtoDate()
+	if pd != (ProtoDate{Year: 2016, Month: 2, Day: 29}) {
+		t.Fatalf("Ожидалось 2016-02-29, получено %+v", pd)
+	}
+
+	data, err := pd.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// поля year, month, day с номерами 1, 2, 3
+	if expected := []byte{0x08, 0xe0, 0x0f, 0x10, 0x02, 0x18, 0x1d}; string(data) != string(expected) {
+		t.Fatalf("Ожидалось %x, получено %x", expected, data)
+	}
+	var pd2 ProtoDate
+	if err := pd2.Unmarshal(data); err != nil {
+		t.Fatal(err)
+	}
+	d2, err := ProtoDateToDate(pd2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d2.Equal(d) || d2.String() != "2016-02-29" {
+		t.Fatalf("Ожидалось %v, получено %v", d, d2)
+	}
+
+	for _, pd := range []ProtoDate{{2017, 2, 29}, {0, 7, 14}, {2017, 0, 0}, {2017, 13, 1}, {10000, 1, 1}, {-1, 1, 1}} {
+		if _, err := ProtoDateToDate(pd); err == nil {
+			t.Fatalf("%+v: ожидалась ошибка", pd)
+		}
+	}
+
+	if pd := MakeNullDate().ProtoDate(); pd != nil {
+		t.Fatalf("Ожидалось nil, получено %v", pd)
+	}
+	if nd, err := ProtoDateToNullDate(&pd); !nd.Valid || err != nil || !nd.Equal(d) {
+		t.Fatalf("Ожидалось %v, получено %v, %v", d, nd, err)
+	}
+	if nd, err := ProtoDateToNullDate(&ProtoDate{}); nd.Valid || err == nil {
+		t.Fatalf("Ожидалась ошибка, получено %v", nd)
+	}
+}
+
+func TestProtoUnmarshalErrors(t *testing.T) {
+	var ts ProtoTimestamp
+	// отрицательные int32 кодируются десятью байтами, неизвестные поля пропускаются
+	data := []byte{0x10, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0x1a, 0x01, 'x', 0x08, 0x05}
+	if err := ts.Unmarshal(data); err != nil || ts.Seconds != 5 || ts.Nanos != -1 {
+		t.Fatalf("Ожидалось {5 -1}, получено %+v, %v", ts, err)
+	}
+	for _, data := range [][]byte{{0x08}, {0x1a, 0x05}, {0x0d, 0x01}, {0x0b}, {0x80}, {0x00, 0x01}} {
+		if err := ts.Unmarshal(data); err == nil {
+			t.Fatalf("%x: ожидалась ошибка", data)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package types
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ValuesDecoder описывает тип, который умеет устанавливать своё значение
+// из параметра key набора url.Values. Обратен интерфейсу query.Encoder
+type ValuesDecoder interface {
+	DecodeValues(key string, v url.Values) error
+}
+
+var (
+	valuesDecoderType   = reflect.TypeOf((*ValuesDecoder)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// DecodeValues реализует интерфейс ValuesDecoder для объекта DateTime
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout.
+// Если параметр key отсутствует, значение не меняется
+func (d *DateTime) DecodeValues(key string, v url.Values) error {
+	if _, ok := v[key]; !ok {
+		return nil
+	}
+	return d.UnmarshalText([]byte(v.Get(key)))
+}
+
+// DecodeValues реализует интерфейс ValuesDecoder для объекта Date
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout.
+// Если параметр key отсутствует, значение не меняется
+func (d *Date) DecodeValues(key string, v url.Values) error {
+	if _, ok := v[key]; !ok {
+		return nil
+	}
+	return d.UnmarshalText([]byte(v.Get(key)))
+}
+
+// DecodeValues реализует интерфейс ValuesDecoder для объекта YearMonth
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout.
+// Если параметр key отсутствует, значение не меняется
+func (ym *YearMonth) DecodeValues(key string, v url.Values) error {
+	if _, ok := v[key]; !ok {
+		return nil
+	}
+	return ym.UnmarshalText([]byte(v.Get(key)))
+}
+
+// DecodeValues реализует интерфейс ValuesDecoder для объекта YearWeek
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout.
+// Если параметр key отсутствует, значение не меняется
+func (yw *YearWeek) DecodeValues(key string, v url.Values) error {
+	if _, ok := v[key]; !ok {
+		return nil
+	}
+	return yw.UnmarshalText([]byte(v.Get(key)))
+}
+
+// DecodeValues реализует интерфейс ValuesDecoder для объекта NullDateTime
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout.
+// Отсутствующий или пустой параметр key преобразуется в значение NULL
+func (d *NullDateTime) DecodeValues(key string, v url.Values) error {
+	return d.UnmarshalText([]byte(v.Get(key)))
+}
+
+// DecodeValues реализует интерфейс ValuesDecoder для объекта NullDate
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout.
+// Отсутствующий или пустой параметр key преобразуется в значение NULL
+func (d *NullDate) DecodeValues(key string, v url.Values) error {
+	return d.UnmarshalText([]byte(v.Get(key)))
+}
+
+// DecodeQuery заполняет поля структуры, на которую указывает dst, значениями
+// из values. Имена параметров берутся из тега url, как в go-querystring;
+// поля вложенных структур ищутся по именам вида "parent[child]".
+// Поддерживаются типы, реализующие ValuesDecoder или encoding.TextUnmarshaler
+// (DateTime, Date, их Null-варианты, YearMonth, YearWeek, decimal.Decimal), строки, логические
+// и числовые типы, указатели и срезы на них.
+// Ошибки разбора значений возвращаются в виде Validation с ключами - именами
+// параметров; ошибка возвращается, только если dst не является указателем на структуру
+func DecodeQuery(values url.Values, dst interface{}) (Validation, error) {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil, errors.New("DecodeQuery: dst должен быть указателем на структуру")
+	}
+	errs := NewValidation()
+	decodeStruct(values, v.Elem(), "", errs)
+	return errs, nil
+}
+
+// decodeStruct заполняет поля структуры sv, scope задаёт префикс имён параметров
+func decodeStruct(values url.Values, sv reflect.Value, scope string, errs Validation) {
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		field := st.Field(i)
+		fv := sv.Field(i)
+		tag := field.Tag.Get("url")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		// поля встроенной структуры без имени в теге разбираются как поля внешней,
+		// даже если сама встроенная структура не экспортируется
+		if name == "" && field.Anonymous && fv.Kind() == reflect.Struct && !isDecodable(fv.Addr().Type()) {
+			decodeStruct(values, fv, scope, errs)
+			continue
+		}
+		if !fv.CanSet() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if scope != "" {
+			name = scope + "[" + name + "]"
+		}
+
+		if err := decodeField(values, fv, name, errs); err != nil {
+			errs.AddError(name, fmt.Sprintf("Неверное значение %q: %s", values.Get(name), err))
+		}
+	}
+}
+
+// isDecodable проверяет, умеет ли тип t сам разбирать своё значение
+func isDecodable(t reflect.Type) bool {
+	return t.Implements(valuesDecoderType) || t.Implements(textUnmarshalerType)
+}
+
+// decodeField устанавливает значение поля fv из параметра name
+func decodeField(values url.Values, fv reflect.Value, name string, errs Validation) error {
+	if fv.Kind() == reflect.Ptr {
+		if !hasValues(values, name) {
+			return nil
+		}
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		return decodeField(values, fv.Elem(), name, errs)
+	}
+	if fv.Addr().Type().Implements(valuesDecoderType) {
+		return fv.Addr().Interface().(ValuesDecoder).DecodeValues(name, values)
+	}
+	if fv.Kind() == reflect.Struct && !isDecodable(fv.Addr().Type()) {
+		decodeStruct(values, fv, name, errs)
+		return nil
+	}
+
+	raw, ok := values[name]
+	if !ok {
+		return nil
+	}
+	if fv.Kind() == reflect.Slice && !isDecodable(fv.Addr().Type()) {
+		slice := reflect.MakeSlice(fv.Type(), len(raw), len(raw))
+		for i, s := range raw {
+			if err := decodeString(slice.Index(i), s); err != nil {
+				return err
+			}
+		}
+		fv.Set(slice)
+		return nil
+	}
+	return decodeString(fv, raw[0])
+}
+
+// hasValues проверяет, есть ли в values параметр name или параметры вложенной структуры name
+func hasValues(values url.Values, name string) bool {
+	if _, ok := values[name]; ok {
+		return true
+	}
+	for key := range values {
+		if strings.HasPrefix(key, name+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeString устанавливает значение fv из строки s
+func decodeString(fv reflect.Value, s string) error {
+	if fv.Addr().Type().Implements(textUnmarshalerType) {
+		return fv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	case reflect.Ptr:
+		elem := reflect.New(fv.Type().Elem())
+		if err := decodeString(elem.Elem(), s); err != nil {
+			return err
+		}
+		fv.Set(elem)
+	default:
+		return fmt.Errorf("неподдерживаемый тип %s", fv.Type())
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package types
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/mihteh/types/decimal"
+)
+
+func TestDateTimeDateDecodeValues(t *testing.T) {
+	values := url.Values{
+		"dt":    {"2017-07-14 15:04:05"},
+		"d":     {"14.07.2017"},
+		"empty": {""},
+	}
+
+	var dt DateTime
+	if err := dt.DecodeValues("dt", values); err != nil {
+		t.Fatal(err)
+	}
+	if s := dt.String(); s != "2017-07-14 15:04:05" {
+		t.Fatalf("Ожидалось 2017-07-14 15:04:05, получено %s", s)
+	}
+
+	d := Date{Layout: GraphsDateLayout}
+	if err := d.DecodeValues("d", values); err != nil {
+		t.Fatal(err)
+	}
+	if s := d.Time.Format(DateLayout); s != "2017-07-14" {
+		t.Fatalf("Ожидалось 2017-07-14, получено %s", s)
+	}
+	if err := d.DecodeValues("missing", values); err != nil || d.Time.Format(DateLayout) != "2017-07-14" {
+		t.Fatalf("Значение не должно было измениться, получено %v, %v", d, err)
+	}
+	if err := d.DecodeValues("dt", values); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+
+	nd := DateNow().Nullable()
+	if err := nd.DecodeValues("empty", values); err != nil || nd.Valid {
+		t.Fatalf("Ожидалось NULL, получено %v, %v", nd, err)
+	}
+	var ndt NullDateTime
+	if err := ndt.DecodeValues("dt", values); err != nil || !ndt.Valid {
+		t.Fatalf("Ожидалось валидное значение, получено %v, %v", ndt, err)
+	}
+	if err := ndt.DecodeValues("missing", values); err != nil || ndt.Valid {
+		t.Fatalf("Ожидалось NULL, получено %v, %v", ndt, err)
+	}
+}
+
+func TestDecodeQuery(t *testing.T) {
+	type paging struct {
+		Page  int `url:"page"`
+		Limit *int
+	}
+	type filter struct {
+		paging
+		From    Date            `url:"from"`
+		To      NullDate        `url:"to"`
+		Created *DateTime       `url:"created"`
+		Amount  decimal.Decimal `url:"amount"`
+		Max     *decimal.Decimal
+		Tags    []string `url:"tag"`
+		Active  bool     `url:"active,omitempty"`
+		Range   struct {
+			Min float64 `url:"min"`
+		} `url:"range"`
+		Ignored string `url:"-"`
+		hidden  string
+	}
+	values := url.Values{
+		"page":       {"2"},
+		"from":       {"2017-07-01"},
+		"created":    {"2017-07-14 15:04:05"},
+		"amount":     {"1234.50"},
+		"tag":        {"a", "b"},
+		"active":     {"true"},
+		"range[min]": {"1.5"},
+		"Ignored":    {"x"},
+		"hidden":     {"x"},
+	}
+
+	var f filter
+	errs, err := DecodeQuery(values, &f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs.HasErrors() {
+		t.Fatalf("Неожиданные ошибки: %v", errs)
+	}
+	if f.Page != 2 || f.Limit != nil || f.From.String() != "2017-07-01" || f.To.Valid ||
+		f.Created == nil || f.Created.String() != "2017-07-14 15:04:05" ||
+		!f.Amount.Equals(decimal.New(123450, -2)) || f.Max != nil ||
+		!reflect.DeepEqual(f.Tags, []string{"a", "b"}) || !f.Active || f.Range.Min != 1.5 ||
+		f.Ignored != "" || f.hidden != "" {
+		t.Fatalf("Неверный результат: %+v", f)
+	}
+}
+
+func TestDecodeQueryErrors(t *testing.T) {
+	type form struct {
+		From   Date            `url:"from"`
+		To     NullDateTime    `url:"to"`
+		Amount decimal.Decimal `url:"amount"`
+		Count  int             `url:"count"`
+		Name   string          `url:"name"`
+	}
+	values := url.Values{
+		"from":   {"14.07.2017"},
+		"to":     {"wrong"},
+		"amount": {"1,5"},
+		"count":  {"many"},
+		"name":   {"ok"},
+	}
+
+	var f form
+	errs, err := DecodeQuery(values, &f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"from", "to", "amount", "count"} {
+		if len(errs[key]) != 1 {
+			t.Fatalf("Ожидалась ошибка для %s, получено %v", key, errs)
+		}
+	}
+	if _, ok := errs["name"]; ok || f.Name != "ok" {
+		t.Fatalf("Неожиданная ошибка для name: %v", errs)
+	}
+
+	if _, err := DecodeQuery(values, f); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+}
+
+func TestDecodeQueryYearPeriods(t *testing.T) {
+	type report struct {
+		Month YearMonth `url:"month"`
+		Week  YearWeek  `url:"week"`
+		Short YearWeek  `url:"short"`
+		Prev  *YearWeek `url:"prev"`
+	}
+	values := url.Values{
+		"month": {"2017-07"},
+		"week":  {"2017-W28"},
+		"short": {"2017W29"},
+		"prev":  {"2017-W27"},
+	}
+
+	r := report{Short: YearWeek{Layout: "%04dW%02d"}}
+	errs, err := DecodeQuery(values, &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs.HasErrors() {
+		t.Fatalf("Неожиданные ошибки: %v", errs)
+	}
+	if r.Month.String() != "2017-07" || r.Week.String() != "2017-W28" || r.Short.String() != "2017W29" ||
+		r.Prev == nil || r.Prev.String() != "2017-W27" {
+		t.Fatalf("Неверный результат: %+v", r)
+	}
+
+	errs, err = DecodeQuery(url.Values{"month": {"2017-13"}, "week": {"2017-W53"}}, &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs["month"]) != 1 || len(errs["week"]) != 1 {
+		t.Fatalf("Ожидались ошибки для month и week, получено %v", errs)
+	}
+}
@@ -0,0 +1,633 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency задаёт частоту повторения в правиле RRule (параметр FREQ)
+type Frequency int
+
+// Поддерживаемые значения FREQ
+const (
+	Yearly Frequency = iota
+	Monthly
+	Weekly
+	Daily
+)
+
+// frequencyNames хранит текстовые представления Frequency согласно RFC 5545
+var frequencyNames = map[Frequency]string{
+	Yearly:  "YEARLY",
+	Monthly: "MONTHLY",
+	Weekly:  "WEEKLY",
+	Daily:   "DAILY",
+}
+
+// weekdayNames хранит двухбуквенные обозначения дней недели согласно RFC 5545
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Шаблоны параметра UNTIL
+const (
+	rruleDateLayout     = "20060102"
+	rruleDateTimeLayout = "20060102T150405"
+)
+
+// rruleMaxYear ограничивает перебор периодов сверху
+const rruleMaxYear = 9999
+
+// rruleCyclePeriods хранит количество периодов в 400-летнем цикле григорианского
+// календаря, после которого дни недели и високосные годы повторяются. Если за
+// столько периодов подряд не найдено ни одного повторения, правило больше
+// никогда не сработает
+var rruleCyclePeriods = map[Frequency]int{
+	Yearly:  400,
+	Monthly: 400 * 12,
+	Weekly:  146097 / 7,
+	Daily:   146097,
+}
+
+// ErrRRuleUnbounded возвращается при попытке получить все повторения правила,
+// в котором не задано ни COUNT, ни UNTIL
+var ErrRRuleUnbounded = errors.New("Правило не ограничено ни COUNT, ни UNTIL")
+
+// String возвращает значение FREQ для частоты f
+func (f Frequency) String() string {
+	return frequencyNames[f]
+}
+
+// RRuleDay задаёт элемент параметра BYDAY: день недели и, если N != 0,
+// его порядковый номер в месяце или году (отрицательный - считая с конца)
+type RRuleDay struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// String возвращает представление элемента BYDAY, например "MO", "2MO" или "-1FR"
+func (d RRuleDay) String() string {
+	if d.N == 0 {
+		return weekdayNames[d.Weekday]
+	}
+	return strconv.Itoa(d.N) + weekdayNames[d.Weekday]
+}
+
+// RRule описывает правило повторения - подмножество RRULE из RFC 5545:
+// FREQ, INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY, BYMONTH и BYSETPOS.
+// Недели в правилах с FREQ=WEEKLY начинаются с понедельника.
+// Нулевой Interval равнозначен 1.
+type RRule struct {
+	Freq     Frequency
+	Interval int
+	Count    int
+	Until    NullDateTime
+	// UntilDate означает, что UNTIL задан датой без времени (DATE) и включает
+	// в себя весь указанный день
+	UntilDate  bool
+	ByDay      []RRuleDay
+	ByMonthDay []int
+	ByMonth    []time.Month
+	BySetPos   []int
+}
+
+// ParseRRule формирует объект RRule на основе строки s вида
+// "FREQ=MONTHLY;BYDAY=MO;BYSETPOS=2", допускается префикс "RRULE:"
+func ParseRRule(s string) (RRule, error) {
+	r := RRule{Interval: 1}
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	hasFreq := false
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return RRule{}, fmt.Errorf("Неверный параметр правила повторения: %q", part)
+		}
+		name, value := strings.ToUpper(kv[0]), kv[1]
+		var err error
+		switch name {
+		case "FREQ":
+			hasFreq = true
+			r.Freq, err = parseFrequency(value)
+		case "INTERVAL":
+			if r.Interval, err = strconv.Atoi(value); err == nil && r.Interval < 1 {
+				err = fmt.Errorf("значение должно быть положительным, получено %d", r.Interval)
+			}
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+		case "UNTIL":
+			r.Until, r.UntilDate, err = parseRRuleUntil(value)
+		case "BYDAY":
+			r.ByDay, err = parseRRuleDays(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseInts(value)
+		case "BYMONTH":
+			var months []int
+			months, err = parseInts(value)
+			for _, m := range months {
+				r.ByMonth = append(r.ByMonth, time.Month(m))
+			}
+		case "BYSETPOS":
+			r.BySetPos, err = parseInts(value)
+		default:
+			return RRule{}, fmt.Errorf("Неподдерживаемый параметр правила повторения: %s", name)
+		}
+		if err != nil {
+			return RRule{}, fmt.Errorf("Ошибка разбора параметра %s: %s", name, err)
+		}
+	}
+	if !hasFreq {
+		return RRule{}, errors.New("В правиле повторения не задан параметр FREQ")
+	}
+	if err := r.Validate(); err != nil {
+		return RRule{}, err
+	}
+	return r, nil
+}
+
+func parseFrequency(s string) (Frequency, error) {
+	for f, name := range frequencyNames {
+		if strings.ToUpper(s) == name {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("неподдерживаемая частота %q", s)
+}
+
+// parseRRuleUntil разбирает значение UNTIL и сообщает, задано ли оно датой без времени
+func parseRRuleUntil(s string) (NullDateTime, bool, error) {
+	var t time.Time
+	var err error
+	isDate := false
+	switch {
+	case strings.HasSuffix(s, "Z"):
+		t, err = time.ParseInLocation(rruleDateTimeLayout+"Z", s, time.UTC)
+	case strings.Contains(s, "T"):
+		t, err = time.ParseInLocation(rruleDateTimeLayout, s, defaultLocation)
+	default:
+		isDate = true
+		t, err = time.ParseInLocation(rruleDateLayout, s, defaultLocation)
+	}
+	if err != nil {
+		return NullDateTime{}, false, err
+	}
+	dt := NewDateTime()
+	dt.setTime(t)
+	return dt.Nullable(), isDate, nil
+}
+
+func parseRRuleDays(s string) ([]RRuleDay, error) {
+	var days []RRuleDay
+	for _, item := range strings.Split(s, ",") {
+		item = strings.ToUpper(item)
+		if len(item) < 2 {
+			return nil, fmt.Errorf("неверный день недели %q", item)
+		}
+		day := RRuleDay{Weekday: -1}
+		for i, name := range weekdayNames {
+			if strings.HasSuffix(item, name) {
+				day.Weekday = time.Weekday(i)
+			}
+		}
+		if day.Weekday < 0 {
+			return nil, fmt.Errorf("неверный день недели %q", item)
+		}
+		if n := item[:len(item)-2]; n != "" {
+			var err error
+			if day.N, err = strconv.Atoi(n); err != nil {
+				return nil, err
+			}
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func parseInts(s string) ([]int, error) {
+	var res []int
+	for _, item := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, n)
+	}
+	return res, nil
+}
+
+// Validate проверяет правило r на соответствие RFC 5545
+func (r RRule) Validate() error {
+	if _, ok := frequencyNames[r.Freq]; !ok {
+		return fmt.Errorf("Неподдерживаемая частота повторения: %d", r.Freq)
+	}
+	if r.Interval < 0 {
+		return fmt.Errorf("INTERVAL не может быть отрицательным, получено %d", r.Interval)
+	}
+	if r.Count < 0 {
+		return fmt.Errorf("COUNT должен быть положительным, получено %d", r.Count)
+	}
+	if r.Count > 0 && r.Until.Valid {
+		return errors.New("COUNT и UNTIL не могут быть заданы одновременно")
+	}
+	for _, d := range r.ByDay {
+		if d.Weekday < time.Sunday || d.Weekday > time.Saturday {
+			return fmt.Errorf("Неверный день недели в BYDAY: %d", d.Weekday)
+		}
+		if d.N != 0 && r.Freq != Monthly && r.Freq != Yearly {
+			return fmt.Errorf("Номер дня недели в BYDAY допустим только для MONTHLY и YEARLY: %s", d)
+		}
+		if d.N < -53 || d.N > 53 {
+			return fmt.Errorf("Неверный номер дня недели в BYDAY: %s", d)
+		}
+	}
+	if len(r.ByMonthDay) > 0 && r.Freq == Weekly {
+		return errors.New("BYMONTHDAY не допускается для WEEKLY")
+	}
+	for _, n := range r.ByMonthDay {
+		if n == 0 || n < -31 || n > 31 {
+			return fmt.Errorf("Неверный день месяца в BYMONTHDAY: %d", n)
+		}
+	}
+	for _, m := range r.ByMonth {
+		if m < time.January || m > time.December {
+			return fmt.Errorf("Неверный месяц в BYMONTH: %d", m)
+		}
+	}
+	for _, n := range r.BySetPos {
+		if n == 0 || n < -366 || n > 366 {
+			return fmt.Errorf("Неверная позиция в BYSETPOS: %d", n)
+		}
+	}
+	return nil
+}
+
+// String преобразует правило r в строку вида "FREQ=MONTHLY;BYDAY=MO;BYSETPOS=2"
+func (r RRule) String() string {
+	parts := []string{"FREQ=" + r.Freq.String()}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until.Valid {
+		parts = append(parts, "UNTIL="+formatRRuleUntil(r.Until.Time, r.UntilDate))
+	}
+	if len(r.ByMonth) > 0 {
+		months := make([]int, len(r.ByMonth))
+		for i, m := range r.ByMonth {
+			months[i] = int(m)
+		}
+		parts = append(parts, "BYMONTH="+joinInts(months))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = d.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(r.BySetPos))
+	}
+	return strings.Join(parts, ";")
+}
+
+func formatRRuleUntil(t time.Time, isDate bool) string {
+	switch {
+	case isDate:
+		return t.Format(rruleDateLayout)
+	case t.Location() == time.UTC:
+		return t.Format(rruleDateTimeLayout + "Z")
+	default:
+		return t.Format(rruleDateTimeLayout)
+	}
+}
+
+func joinInts(values []int) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = strconv.Itoa(v)
+	}
+	return strings.Join(s, ",")
+}
+
+// MarshalText реализует интерфейс encoding.TextMarshaler для объекта RRule
+func (r RRule) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText реализует интерфейс encoding.TextUnmarshaler для объекта RRule
+func (r *RRule) UnmarshalText(text []byte) error {
+	parsed, err := ParseRRule(string(text))
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// DateTimes возвращает повторения правила r, начиная с start, не более limit штук.
+// Время повторений берётся из start, даты вычисляются в часовом поясе start.
+// Согласно RFC 5545 start всегда является первым повторением и учитывается
+// в COUNT, даже если не удовлетворяет BYDAY, BYMONTHDAY и другим параметрам.
+// UNTIL без времени включает в себя весь указанный день.
+// Если limit <= 0, возвращаются все повторения; для правила без COUNT и UNTIL
+// в этом случае возвращается ошибка ErrRRuleUnbounded.
+func (r RRule) DateTimes(start DateTime, limit int) ([]DateTime, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	if limit <= 0 && r.Count == 0 && !r.Until.Valid {
+		return nil, ErrRRuleUnbounded
+	}
+	start.fixLayout()
+	interval := r.Interval
+	if interval == 0 {
+		interval = 1
+	}
+
+	if r.afterUntil(start.Time) {
+		return nil, nil
+	}
+	res := []DateTime{start}
+	if len(res) == limit || len(res) == r.Count {
+		return res, nil
+	}
+	empty := 0
+	for period := 0; empty < rruleCyclePeriods[r.Freq]; period += interval {
+		candidates, ok := r.candidates(start.Time, period)
+		if !ok {
+			return res, nil
+		}
+		found := false
+		for _, t := range candidates {
+			if !t.After(start.Time) {
+				continue
+			}
+			if r.afterUntil(t) {
+				return res, nil
+			}
+			found = true
+			dt := start
+			dt.setTime(t)
+			res = append(res, dt)
+			if len(res) == limit || len(res) == r.Count {
+				return res, nil
+			}
+		}
+		if found {
+			empty = 0
+		} else {
+			empty++
+		}
+	}
+	return res, nil
+}
+
+// afterUntil проверяет, находится ли t после границы UNTIL правила r.
+// UNTIL без времени (UntilDate) сравнивается с датой t в часовом поясе t
+func (r RRule) afterUntil(t time.Time) bool {
+	if !r.Until.Valid {
+		return false
+	}
+	until := r.Until.Time
+	if r.UntilDate {
+		y, m, d := until.Date()
+		return !t.Before(time.Date(y, m, d+1, 0, 0, 0, 0, t.Location()))
+	}
+	return t.After(until)
+}
+
+// Dates возвращает повторения правила r в виде дат, начиная с start, не более limit штук.
+// Подробнее см. DateTimes.
+func (r RRule) Dates(start Date, limit int) ([]Date, error) {
+	start.fixLayout()
+	dt := NewDateTime()
+	dt.setTime(start.Time)
+	dateTimes, err := r.DateTimes(dt, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]Date, len(dateTimes))
+	for i, item := range dateTimes {
+		d := start
+		d.setTime(item.Time)
+		res[i] = d
+	}
+	return res, nil
+}
+
+// candidates возвращает отсортированные даты-время, удовлетворяющие правилу r
+// в периоде с номером period, отсчитанным от периода, содержащего start.
+// Возвращает false, если период выходит за пределы rruleMaxYear.
+func (r RRule) candidates(start time.Time, period int) ([]time.Time, bool) {
+	var days []time.Time
+	switch r.Freq {
+	case Yearly:
+		year := start.Year() + period
+		if year > rruleMaxYear {
+			return nil, false
+		}
+		days = r.yearDays(start, year)
+	case Monthly:
+		first := setDate(start, start.Year(), start.Month(), 1, start.Hour(), start.Minute(), start.Second()).AddDate(0, period, 0)
+		if first.Year() > rruleMaxYear {
+			return nil, false
+		}
+		if r.matchesMonth(first) {
+			days = r.monthDays(start, first.Year(), first.Month())
+		}
+	case Weekly:
+		offset := (int(start.Weekday()) - int(time.Monday) + 7) % 7
+		first := start.AddDate(0, 0, 7*period-offset)
+		if first.Year() > rruleMaxYear {
+			return nil, false
+		}
+		for i := 0; i < 7; i++ {
+			t := first.AddDate(0, 0, i)
+			if r.matchesWeekday(t, start) && r.matchesMonth(t) {
+				days = append(days, t)
+			}
+		}
+	case Daily:
+		t := start.AddDate(0, 0, period)
+		if t.Year() > rruleMaxYear {
+			return nil, false
+		}
+		if r.matchesMonth(t) && r.matchesMonthDay(t) && r.matchesWeekday(t, t) {
+			days = append(days, t)
+		}
+	}
+	sort.Sort(timeSlice(days))
+	return r.applySetPos(days), true
+}
+
+// yearDays возвращает дни года year, удовлетворяющие правилу с FREQ=YEARLY
+func (r RRule) yearDays(start time.Time, year int) []time.Time {
+	if len(r.ByDay) > 0 && len(r.ByMonth) == 0 {
+		first := setDate(start, year, time.January, 1, start.Hour(), start.Minute(), start.Second())
+		var days []time.Time
+		for _, t := range r.byDayIn(first, first.AddDate(1, 0, 0)) {
+			if r.matchesMonthDay(t) {
+				days = append(days, t)
+			}
+		}
+		return days
+	}
+
+	months := r.ByMonth
+	if len(months) == 0 {
+		if len(r.ByMonthDay) > 0 {
+			for m := time.January; m <= time.December; m++ {
+				months = append(months, m)
+			}
+		} else {
+			months = []time.Month{start.Month()}
+		}
+	}
+	var days []time.Time
+	for _, m := range months {
+		days = append(days, r.monthDays(start, year, m)...)
+	}
+	return days
+}
+
+// monthDays возвращает дни месяца month года year, удовлетворяющие правилу r
+func (r RRule) monthDays(start time.Time, year int, month time.Month) []time.Time {
+	first := setDate(start, year, month, 1, start.Hour(), start.Minute(), start.Second())
+	if len(r.ByDay) > 0 {
+		var days []time.Time
+		for _, t := range r.byDayIn(first, first.AddDate(0, 1, 0)) {
+			if r.matchesMonthDay(t) {
+				days = append(days, t)
+			}
+		}
+		return days
+	}
+
+	last := daysInMonth(year, month)
+	if len(r.ByMonthDay) == 0 {
+		if start.Day() > last {
+			return nil
+		}
+		return []time.Time{first.AddDate(0, 0, start.Day()-1)}
+	}
+	var days []time.Time
+	for _, n := range r.ByMonthDay {
+		if n < 0 {
+			n = last + 1 + n
+		}
+		if n >= 1 && n <= last {
+			days = append(days, first.AddDate(0, 0, n-1))
+		}
+	}
+	return days
+}
+
+// byDayIn возвращает дни интервала [from; to), удовлетворяющие BYDAY,
+// порядковые номера дней недели отсчитываются внутри интервала
+func (r RRule) byDayIn(from, to time.Time) []time.Time {
+	byWeekday := make(map[time.Weekday][]time.Time)
+	for t := from; t.Before(to); t = t.AddDate(0, 0, 1) {
+		byWeekday[t.Weekday()] = append(byWeekday[t.Weekday()], t)
+	}
+	var days []time.Time
+	for _, d := range r.ByDay {
+		list := byWeekday[d.Weekday]
+		switch {
+		case d.N == 0:
+			days = append(days, list...)
+		case d.N > 0 && d.N <= len(list):
+			days = append(days, list[d.N-1])
+		case d.N < 0 && -d.N <= len(list):
+			days = append(days, list[len(list)+d.N])
+		}
+	}
+	return days
+}
+
+// matchesMonth проверяет, удовлетворяет ли t параметру BYMONTH
+func (r RRule) matchesMonth(t time.Time) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, m := range r.ByMonth {
+		if t.Month() == m {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesMonthDay проверяет, удовлетворяет ли t параметру BYMONTHDAY
+func (r RRule) matchesMonthDay(t time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	last := daysInMonth(t.Year(), t.Month())
+	for _, n := range r.ByMonthDay {
+		if n == t.Day() || n < 0 && last+1+n == t.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesWeekday проверяет, удовлетворяет ли t параметру BYDAY без учёта
+// порядковых номеров, а если BYDAY не задан - совпадает ли день недели с def
+func (r RRule) matchesWeekday(t, def time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return t.Weekday() == def.Weekday()
+	}
+	for _, d := range r.ByDay {
+		if t.Weekday() == d.Weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// applySetPos отбирает из отсортированного списка days элементы согласно BYSETPOS
+func (r RRule) applySetPos(days []time.Time) []time.Time {
+	days = uniqueTimes(days)
+	if len(r.BySetPos) == 0 {
+		return days
+	}
+	var res []time.Time
+	for _, n := range r.BySetPos {
+		switch {
+		case n > 0 && n <= len(days):
+			res = append(res, days[n-1])
+		case n < 0 && -n <= len(days):
+			res = append(res, days[len(days)+n])
+		}
+	}
+	sort.Sort(timeSlice(res))
+	return uniqueTimes(res)
+}
+
+// uniqueTimes удаляет повторы из отсортированного списка times
+func uniqueTimes(times []time.Time) []time.Time {
+	var res []time.Time
+	for i, t := range times {
+		if i == 0 || !t.Equal(times[i-1]) {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// timeSlice реализует sort.Interface для среза time.Time
+type timeSlice []time.Time
+
+func (s timeSlice) Len() int           { return len(s) }
+func (s timeSlice) Less(i, j int) bool { return s[i].Before(s[j]) }
+func (s timeSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
@@ -0,0 +1,259 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func datesToStrings(dates []Date) []string {
+	res := make([]string, len(dates))
+	for i, d := range dates {
+		res[i] = d.String()
+	}
+	return res
+}
+
+func TestRRuleDates(t *testing.T) {
+	tests := []struct {
+		rule     string
+		start    string
+		limit    int
+		expected []string
+	}{
+		{ // каждый второй понедельник месяца
+			"FREQ=MONTHLY;BYDAY=2MO", "2017-07-10", 3,
+			[]string{"2017-07-10", "2017-08-14", "2017-09-11"},
+		},
+		{ // последний рабочий день месяца
+			"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", "2017-07-31", 4,
+			[]string{"2017-07-31", "2017-08-31", "2017-09-29", "2017-10-31"},
+		},
+		{ // каждые 3 месяца 15-го числа
+			"FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=15", "2017-07-15", 3,
+			[]string{"2017-07-15", "2017-10-15", "2018-01-15"},
+		},
+		{
+			"FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3", "2016-01-31", 0,
+			[]string{"2016-01-31", "2016-02-29", "2016-03-31"},
+		},
+		{
+			"FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;UNTIL=20170725", "2017-07-11", 0,
+			[]string{"2017-07-11", "2017-07-13", "2017-07-25"},
+		},
+		{
+			"FREQ=DAILY;BYMONTH=2;BYMONTHDAY=28,29;COUNT=3", "2016-02-28", 0,
+			[]string{"2016-02-28", "2016-02-29", "2017-02-28"},
+		},
+		{
+			"FREQ=YEARLY;BYMONTH=1,7;BYDAY=1MO", "2017-01-02", 3,
+			[]string{"2017-01-02", "2017-07-03", "2018-01-01"},
+		},
+		{
+			"FREQ=YEARLY;BYDAY=-1SU", "2017-12-31", 2,
+			[]string{"2017-12-31", "2018-12-30"},
+		},
+		{ // 29 февраля пропускается в невисокосные годы
+			"FREQ=YEARLY;COUNT=2", "2016-02-29", 0,
+			[]string{"2016-02-29", "2020-02-29"},
+		},
+		{ // start всегда является первым повторением, даже если не удовлетворяет правилу
+			"FREQ=MONTHLY;BYDAY=2MO", "2017-07-01", 3,
+			[]string{"2017-07-01", "2017-07-10", "2017-08-14"},
+		},
+		{ // start учитывается в COUNT
+			"FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3", "2016-01-10", 0,
+			[]string{"2016-01-10", "2016-01-31", "2016-02-29"},
+		},
+		{ // start после UNTIL
+			"FREQ=DAILY;UNTIL=20170101", "2017-07-01", 0,
+			[]string{},
+		},
+	}
+	for _, test := range tests {
+		r, err := ParseRRule(test.rule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		start, err := StringToDate(test.start)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dates, err := r.Dates(start, test.limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if received := datesToStrings(dates); !reflect.DeepEqual(received, test.expected) {
+			t.Fatalf("%s: ожидалось %v, получено %v", test.rule, test.expected, received)
+		}
+	}
+}
+
+func TestRRuleDateTimes(t *testing.T) {
+	r := RRule{Freq: Weekly, Count: 2}
+	start, err := StringToDateTime("2017-07-14 09:30:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dateTimes, err := r.DateTimes(start, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"2017-07-14 09:30:00", "2017-07-21 09:30:00"}
+	if len(dateTimes) != len(expected) {
+		t.Fatalf("Ожидалось %d повторений, получено %d", len(expected), len(dateTimes))
+	}
+	for i, dt := range dateTimes {
+		if dt.String() != expected[i] {
+			t.Fatalf("Ожидалось %s, получено %s", expected[i], dt)
+		}
+		if dt.Location() != start.Location() {
+			t.Fatalf("Ожидался часовой пояс %s, получен %s", start.Location(), dt.Location())
+		}
+	}
+}
+
+func TestRRuleUnbounded(t *testing.T) {
+	r := RRule{Freq: Daily}
+	if _, err := r.Dates(DateNow(), 0); err != ErrRRuleUnbounded {
+		t.Fatalf("Ожидалась ошибка %v, получено %v", ErrRRuleUnbounded, err)
+	}
+}
+
+func TestRRuleUntilDate(t *testing.T) {
+	// UNTIL без времени включает весь день, даже если у start задано время
+	r, err := ParseRRule("FREQ=DAILY;UNTIL=20170716")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start, err := StringToDateTime("2017-07-14 09:30:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dateTimes, err := r.DateTimes(start, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"2017-07-14 09:30:00", "2017-07-15 09:30:00", "2017-07-16 09:30:00"}
+	if len(dateTimes) != len(expected) {
+		t.Fatalf("Ожидалось %v, получено %v", expected, dateTimes)
+	}
+	for i, dt := range dateTimes {
+		if dt.String() != expected[i] {
+			t.Fatalf("Ожидалось %s, получено %s", expected[i], dt)
+		}
+	}
+
+	// UNTIL с временем сравнивается с датой-временем повторения
+	r, err = ParseRRule("FREQ=DAILY;UNTIL=20170716T090000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dateTimes, err = r.DateTimes(start, 0); err != nil {
+		t.Fatal(err)
+	}
+	if len(dateTimes) != 2 {
+		t.Fatalf("Ожидалось 2 повторения, получено %v", dateTimes)
+	}
+
+	// UNTIL с временем 00:00:00 не включает остаток дня
+	r, err = ParseRRule("FREQ=DAILY;UNTIL=20170716T000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.UntilDate {
+		t.Fatal("Ожидалось, что UNTIL задан датой-временем")
+	}
+	if dateTimes, err = r.DateTimes(start, 0); err != nil {
+		t.Fatal(err)
+	}
+	if len(dateTimes) != 2 {
+		t.Fatalf("Ожидалось 2 повторения, получено %v", dateTimes)
+	}
+}
+
+func TestRRuleNeverMatches(t *testing.T) {
+	for _, s := range []string{
+		"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+		"FREQ=MONTHLY;BYMONTH=2;BYMONTHDAY=31",
+		"FREQ=DAILY;BYMONTH=2;BYMONTHDAY=31",
+	} {
+		r, err := ParseRRule(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		start, err := StringToDate("2017-01-01")
+		if err != nil {
+			t.Fatal(err)
+		}
+		dates, err := r.Dates(start, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if received := datesToStrings(dates); !reflect.DeepEqual(received, []string{"2017-01-01"}) {
+			t.Fatalf("%s: ожидалось [2017-01-01], получено %v", s, received)
+		}
+	}
+}
+
+func TestRRuleStringRoundTrip(t *testing.T) {
+	rules := []string{
+		"FREQ=DAILY",
+		"FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=15",
+		"FREQ=MONTHLY;COUNT=10;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+		"FREQ=YEARLY;UNTIL=20201231;BYMONTH=1,7;BYDAY=1MO,-1FR",
+		"FREQ=WEEKLY;UNTIL=20170725T120000Z;BYDAY=TU",
+		"FREQ=WEEKLY;UNTIL=20170725T120000;BYDAY=TU",
+		"FREQ=DAILY;UNTIL=20170716T000000",
+	}
+	for _, s := range rules {
+		r, err := ParseRRule("RRULE:" + s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if received := r.String(); received != s {
+			t.Fatalf("Ожидалось %s, получено %s", s, received)
+		}
+	}
+
+	r, err := ParseRRule("BYDAY=2mo;freq=monthly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := RRule{Freq: Monthly, Interval: 1, ByDay: []RRuleDay{{time.Monday, 2}}}
+	if !reflect.DeepEqual(r, expected) {
+		t.Fatalf("Ожидалось %v, получено %v", expected, r)
+	}
+
+	// пустые части, например после завершающей точки с запятой, пропускаются
+	if r, err = ParseRRule("FREQ=DAILY;COUNT=2;"); err != nil {
+		t.Fatal(err)
+	}
+	if s := r.String(); s != "FREQ=DAILY;COUNT=2" {
+		t.Fatalf("Ожидалось FREQ=DAILY;COUNT=2, получено %s", s)
+	}
+}
+
+func TestParseRRuleErrors(t *testing.T) {
+	rules := []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;COUNT=x",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;INTERVAL=-1",
+		"FREQ=DAILY;COUNT=2;UNTIL=20170101",
+		"FREQ=DAILY;BYDAY=XX",
+		"FREQ=WEEKLY;BYDAY=2MO",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=YEARLY;BYMONTH=13",
+		"FREQ=YEARLY;BYSETPOS=0",
+		"FREQ=YEARLY;WKST=MO",
+	}
+	for _, s := range rules {
+		if _, err := ParseRRule(s); err == nil {
+			t.Fatalf("%q: ожидалась ошибка", s)
+		}
+	}
+}
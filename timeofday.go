@@ -0,0 +1,360 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Шаблоны для сериализации времени суток
+const (
+	TimeOfDayLayout      = "15:04:05"
+	TimeOfDayShortLayout = "15:04"
+)
+
+// secondsInDay количество секунд в сутках
+const secondsInDay = 24 * 60 * 60
+
+// TimeOfDay хранит время суток с точностью до секунд и шаблон для преобразования
+// при сериализации. Соответствует типу TIME в SQL.
+type TimeOfDay struct {
+	seconds int
+	Layout  string
+}
+
+// NewTimeOfDay создаёт объект TimeOfDay с заданными часами, минутами и секундами
+// и шаблоном вывода по умолчанию TimeOfDayLayout.
+// Значения за пределами суток переносятся через полночь: 25:00:00 = 01:00:00
+func NewTimeOfDay(hours, mins, secs int) TimeOfDay {
+	return TimeOfDay{Layout: TimeOfDayLayout}.setSeconds(hours*3600 + mins*60 + secs)
+}
+
+// ToTimeOfDay формирует объект TimeOfDay на основе времени t
+func ToTimeOfDay(t time.Time) TimeOfDay {
+	return NewTimeOfDay(t.Hour(), t.Minute(), t.Second())
+}
+
+// TimeOfDayNow возвращает объект TimeOfDay, соответствующий времени сейчас
+func TimeOfDayNow() TimeOfDay {
+	return ToTimeOfDay(time.Now().In(defaultLocation))
+}
+
+// StringToTimeOfDay формирует объект TimeOfDay на основе строки s,
+// заданной по шаблону TimeOfDayLayout или TimeOfDayShortLayout
+func StringToTimeOfDay(s string) (TimeOfDay, error) {
+	return parseTimeOfDay(TimeOfDayLayout, s)
+}
+
+// parseTimeOfDay разбирает строку s по шаблону layout, а при неудаче -
+// по шаблонам TimeOfDayLayout и TimeOfDayShortLayout
+func parseTimeOfDay(layout, s string) (TimeOfDay, error) {
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		for _, l := range []string{TimeOfDayLayout, TimeOfDayShortLayout} {
+			var err2 error
+			if t, err2 = time.Parse(l, s); err2 == nil {
+				err = nil
+				break
+			}
+		}
+	}
+	if err != nil {
+		return TimeOfDay{}, err
+	}
+	tod := ToTimeOfDay(t)
+	tod.Layout = layout
+	return tod, nil
+}
+
+// setSeconds возвращает копию t с заданным количеством секунд от начала суток
+// с переносом через полночь
+func (t TimeOfDay) setSeconds(seconds int) TimeOfDay {
+	t.seconds = (seconds%secondsInDay + secondsInDay) % secondsInDay
+	return t
+}
+
+// fixLayout устанавливает Layout в объекте TimeOfDay на TimeOfDayLayout если он не определён
+func (t *TimeOfDay) fixLayout() {
+	if t.Layout == "" {
+		t.Layout = TimeOfDayLayout
+	}
+}
+
+// Hour возвращает часы
+func (t TimeOfDay) Hour() int {
+	return t.seconds / 3600
+}
+
+// Minute возвращает минуты
+func (t TimeOfDay) Minute() int {
+	return t.seconds % 3600 / 60
+}
+
+// Second возвращает секунды
+func (t TimeOfDay) Second() int {
+	return t.seconds % 60
+}
+
+// SinceMidnight возвращает время, прошедшее с начала суток
+func (t TimeOfDay) SinceMidnight() time.Duration {
+	return time.Duration(t.seconds) * time.Second
+}
+
+// Add прибавляет к t длительность d с точностью до секунд, с переносом через полночь
+func (t TimeOfDay) Add(d time.Duration) TimeOfDay {
+	return t.setSeconds(t.seconds + int(d/time.Second))
+}
+
+// Sub возвращает длительность t - t1 в пределах одних суток
+func (t TimeOfDay) Sub(t1 TimeOfDay) time.Duration {
+	return time.Duration(t.seconds-t1.seconds) * time.Second
+}
+
+// Until возвращает длительность от t до ближайшего наступления t1,
+// с переносом через полночь: от 23:00 до 01:00 - 2 часа
+func (t TimeOfDay) Until(t1 TimeOfDay) time.Duration {
+	return time.Duration((t1.seconds-t.seconds+secondsInDay)%secondsInDay) * time.Second
+}
+
+// After возвращает true если время t позднее t1, иначе false
+func (t TimeOfDay) After(t1 TimeOfDay) bool {
+	return t.seconds > t1.seconds
+}
+
+// Before возвращает true если время t ранее t1, иначе false
+func (t TimeOfDay) Before(t1 TimeOfDay) bool {
+	return t.seconds < t1.seconds
+}
+
+// Between возвращает true если время t находится в интервале (t1; t2), иначе false
+func (t TimeOfDay) Between(t1, t2 TimeOfDay) bool {
+	return t.After(t1) && t.Before(t2)
+}
+
+// Equal возвращает true если время t равно t1, иначе false
+func (t TimeOfDay) Equal(t1 TimeOfDay) bool {
+	return t.seconds == t1.seconds
+}
+
+// Pointer возвращает указатель на объект TimeOfDay
+func (t TimeOfDay) Pointer() *TimeOfDay {
+	return &t
+}
+
+// String преобразует объект TimeOfDay в строку согласно шаблона в свойстве Layout
+func (t TimeOfDay) String() string {
+	t.fixLayout()
+	return time.Date(0, time.January, 1, t.Hour(), t.Minute(), t.Second(), 0, time.UTC).Format(t.Layout)
+}
+
+// WithTimeOfDay возвращает объект DateTime с датой d и временем t
+func (d Date) WithTimeOfDay(t TimeOfDay) DateTime {
+	return d.ConvertToDateTimeHMS(t.Hour(), t.Minute(), t.Second())
+}
+
+// TimeOfDay возвращает время суток даты-времени d
+func (d DateTime) TimeOfDay() TimeOfDay {
+	return ToTimeOfDay(d.Time)
+}
+
+// UnmarshalJSON - реализует интерфейс json.Unmarshaler для объекта TimeOfDay
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (t *TimeOfDay) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	t.fixLayout()
+	parsed, err := parseTimeOfDay(t.Layout, s)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalJSON - реализует интерфейс json.Marshaler для объекта TimeOfDay
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(t.String())), nil
+}
+
+// EncodeValues реализует интерфейс query.Encoder для объекта TimeOfDay
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (t TimeOfDay) EncodeValues(key string, v *url.Values) error {
+	v.Set(key, t.String())
+	return nil
+}
+
+// UnmarshalXML реализует интерфейс xml.Unmarshaler для объекта TimeOfDay
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (t *TimeOfDay) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	var content string
+	if err := decoder.DecodeElement(&content, &start); err != nil {
+		return err
+	}
+	t.fixLayout()
+	parsed, err := parseTimeOfDay(t.Layout, content)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalXML реализует интерфейс xml.Marshaler для объекта TimeOfDay
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (t TimeOfDay) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	return encoder.EncodeElement(t.String(), start)
+}
+
+// Scan преобразует значение времени в БД к типу TimeOfDay
+// Реализует интерфейс sql.Scanner
+func (t *TimeOfDay) Scan(value interface{}) error {
+	t.fixLayout()
+	layout := t.Layout
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		*t = ToTimeOfDay(v)
+	case []byte:
+		parsed, err := parseTimeOfDay(TimeOfDayLayout, string(v))
+		if err != nil {
+			return err
+		}
+		*t = parsed
+	case string:
+		parsed, err := parseTimeOfDay(TimeOfDayLayout, v)
+		if err != nil {
+			return err
+		}
+		*t = parsed
+	default:
+		return fmt.Errorf("Ошибка преобразования значения %v к типу TimeOfDay", value)
+	}
+	t.Layout = layout
+	return nil
+}
+
+// Value преобразует значение типа TimeOfDay к значению в БД
+// Реализует интерфейс driver.Valuer
+func (t TimeOfDay) Value() (driver.Value, error) {
+	t.Layout = TimeOfDayLayout
+	return t.String(), nil
+}
+
+// NullTimeOfDay это вспомогательный тип, необходимый для реализации
+// интерфейса Valuer на указателе
+type NullTimeOfDay struct {
+	TimeOfDay
+	Valid bool
+}
+
+// MakeNullTimeOfDay возвращает NullTimeOfDay со значением NULL
+func MakeNullTimeOfDay() NullTimeOfDay {
+	return NullTimeOfDay{Valid: false}
+}
+
+// Nullable преобразует тип TimeOfDay в тип NullTimeOfDay
+func (t TimeOfDay) Nullable() NullTimeOfDay {
+	return NullTimeOfDay{
+		TimeOfDay: t,
+		Valid:     true,
+	}
+}
+
+// Scan преобразует значение времени в БД к типу NullTimeOfDay
+// Реализует интерфейс sql.Scanner
+func (t *NullTimeOfDay) Scan(value interface{}) error {
+	t.fixLayout()
+	if value == nil {
+		t.Valid = false
+		return nil
+	}
+	err := t.TimeOfDay.Scan(value)
+	t.Valid = err == nil
+	return err
+}
+
+// Value преобразует значение типа NullTimeOfDay к значению в БД
+// Реализует интерфейс driver.Valuer
+func (t NullTimeOfDay) Value() (driver.Value, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	return t.TimeOfDay.Value()
+}
+
+// String преобразует объект NullTimeOfDay в строку согласно шаблона в свойстве Layout
+func (t NullTimeOfDay) String() string {
+	if !t.Valid {
+		return "null"
+	}
+	return t.TimeOfDay.String()
+}
+
+// UnmarshalJSON - реализует интерфейс json.Unmarshaler для объекта NullTimeOfDay
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (t *NullTimeOfDay) UnmarshalJSON(data []byte) error {
+	isNil, err := isJSONBytesNil(data)
+	if err != nil {
+		return err
+	}
+	if isNil {
+		t.Valid = false
+		return nil
+	}
+	err = t.TimeOfDay.UnmarshalJSON(data)
+	t.Valid = err == nil
+	return err
+}
+
+// MarshalJSON - реализует интерфейс json.Marshaler для объекта NullTimeOfDay
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (t NullTimeOfDay) MarshalJSON() ([]byte, error) {
+	if !t.Valid {
+		return []byte("null"), nil
+	}
+	return t.TimeOfDay.MarshalJSON()
+}
+
+// EncodeValues реализует интерфейс query.Encoder для объекта NullTimeOfDay
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (t NullTimeOfDay) EncodeValues(key string, v *url.Values) error {
+	if !t.Valid {
+		return nil
+	}
+	return t.TimeOfDay.EncodeValues(key, v)
+}
+
+// UnmarshalXML реализует интерфейс xml.Unmarshaler для объекта NullTimeOfDay
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (t *NullTimeOfDay) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	var content string
+	if err := decoder.DecodeElement(&content, &start); err != nil {
+		return err
+	}
+	if content == "" {
+		t.Valid = false
+		return nil
+	}
+	t.fixLayout()
+	parsed, err := parseTimeOfDay(t.Layout, content)
+	t.TimeOfDay = parsed
+	t.Valid = err == nil
+	return err
+}
+
+// MarshalXML реализует интерфейс xml.Marshaler для объекта NullTimeOfDay
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (t NullTimeOfDay) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	if !t.Valid {
+		return encoder.EncodeElement(nil, start)
+	}
+	return t.TimeOfDay.MarshalXML(encoder, start)
+}
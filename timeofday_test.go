@@ -0,0 +1,174 @@
+package types
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestTimeOfDayStringConversion(t *testing.T) {
+	tod, err := StringToTimeOfDay("09:05:07")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := tod.String(); s != "09:05:07" {
+		t.Fatalf("Ожидалось 09:05:07, получено %s", s)
+	}
+
+	tod, err = StringToTimeOfDay("18:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := tod.String(); s != "18:30:00" {
+		t.Fatalf("Ожидалось 18:30:00, получено %s", s)
+	}
+	tod.Layout = TimeOfDayShortLayout
+	if s := tod.String(); s != "18:30" {
+		t.Fatalf("Ожидалось 18:30, получено %s", s)
+	}
+
+	if _, err := StringToTimeOfDay("wrong"); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+}
+
+func TestTimeOfDayArithmetic(t *testing.T) {
+	tod := NewTimeOfDay(23, 30, 0)
+	if s := tod.Add(time.Hour).String(); s != "00:30:00" {
+		t.Fatalf("Ожидалось 00:30:00, получено %s", s)
+	}
+	if s := tod.Add(-24*time.Hour - time.Second).String(); s != "23:29:59" {
+		t.Fatalf("Ожидалось 23:29:59, получено %s", s)
+	}
+	if s := NewTimeOfDay(25, -1, 0).String(); s != "00:59:00" {
+		t.Fatalf("Ожидалось 00:59:00, получено %s", s)
+	}
+
+	morning := NewTimeOfDay(1, 0, 0)
+	if d := morning.Sub(tod); d != -(22*time.Hour + 30*time.Minute) {
+		t.Fatalf("Ожидалось -22h30m, получено %v", d)
+	}
+	if d := tod.Until(morning); d != 90*time.Minute {
+		t.Fatalf("Ожидалось 1h30m, получено %v", d)
+	}
+
+	if !morning.Before(tod) || morning.After(tod) || !tod.Equal(NewTimeOfDay(23, 30, 0)) {
+		t.Fatal("Ошибка сравнения")
+	}
+	if !NewTimeOfDay(12, 0, 0).Between(morning, tod) || morning.Between(morning, tod) {
+		t.Fatal("Ошибка Between")
+	}
+}
+
+func TestTimeOfDayWithDate(t *testing.T) {
+	d, err := StringToDate("2017-07-14")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dt := d.WithTimeOfDay(NewTimeOfDay(15, 4, 5))
+	if s := dt.String(); s != "2017-07-14 15:04:05" {
+		t.Fatalf("Ожидалось 2017-07-14 15:04:05, получено %s", s)
+	}
+	if s := dt.TimeOfDay().String(); s != "15:04:05" {
+		t.Fatalf("Ожидалось 15:04:05, получено %s", s)
+	}
+}
+
+func TestTimeOfDayJSONXML(t *testing.T) {
+	type schedule struct {
+		Opens  TimeOfDay
+		Closes NullTimeOfDay
+		Break  NullTimeOfDay
+	}
+	s := schedule{
+		Opens:  NewTimeOfDay(9, 0, 0),
+		Closes: NewTimeOfDay(21, 30, 0).Nullable(),
+		Break:  MakeNullTimeOfDay(),
+	}
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"Opens":"09:00:00","Closes":"21:30:00","Break":null}`
+	if string(b) != expected {
+		t.Fatalf("Ожидалось %s, получено %s", expected, b)
+	}
+	var fromJSON schedule
+	if err := json.Unmarshal(b, &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	if !fromJSON.Opens.Equal(s.Opens) || !fromJSON.Closes.Valid || !fromJSON.Closes.Equal(s.Closes.TimeOfDay) || fromJSON.Break.Valid {
+		t.Fatalf("Ожидалось %v, получено %v", s, fromJSON)
+	}
+
+	b, err = xml.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = `<schedule><Opens>09:00:00</Opens><Closes>21:30:00</Closes></schedule>`
+	if string(b) != expected {
+		t.Fatalf("Ожидалось %s, получено %s", expected, b)
+	}
+	var fromXML schedule
+	if err := xml.Unmarshal(b, &fromXML); err != nil {
+		t.Fatal(err)
+	}
+	if !fromXML.Opens.Equal(s.Opens) || !fromXML.Closes.Valid || fromXML.Break.Valid {
+		t.Fatalf("Ожидалось %v, получено %v", s, fromXML)
+	}
+
+	if err := json.Unmarshal([]byte(`"wrong"`), &fromJSON.Opens); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+}
+
+func TestTimeOfDayUrlEncode(t *testing.T) {
+	v := url.Values{}
+	tod := NewTimeOfDay(7, 45, 0)
+	tod.Layout = TimeOfDayShortLayout
+	if err := tod.EncodeValues("at", &v); err != nil {
+		t.Fatal(err)
+	}
+	if err := MakeNullTimeOfDay().EncodeValues("none", &v); err != nil {
+		t.Fatal(err)
+	}
+	if s := v.Encode(); s != "at=07%3A45" {
+		t.Fatalf("Ожидалось at=07%%3A45, получено %s", s)
+	}
+}
+
+func TestTimeOfDayScanValueForDB(t *testing.T) {
+	var tod TimeOfDay
+	for _, value := range []interface{}{[]byte("13:14:15.123456"), "13:14:15", time.Date(0, 1, 1, 13, 14, 15, 0, time.UTC)} {
+		if err := tod.Scan(value); err != nil {
+			t.Fatal(err)
+		}
+		v, err := tod.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != "13:14:15" {
+			t.Fatalf("Ожидалось 13:14:15, получено %v", v)
+		}
+	}
+	if err := tod.Scan(42); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+
+	var ntod NullTimeOfDay
+	if err := ntod.Scan(nil); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := ntod.Value(); err != nil || v != nil {
+		t.Fatalf("Ожидалось nil, получено %v, %v", v, err)
+	}
+	if err := ntod.Scan("08:00:00"); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := ntod.Value(); err != nil || v != "08:00:00" {
+		t.Fatalf("Ожидалось 08:00:00, получено %v, %v", v, err)
+	}
+}
@@ -0,0 +1,481 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// YearMonthLayout - шаблон по умолчанию для сериализации месяца года
+const YearMonthLayout = "2006-01"
+
+// YearWeekLayout - шаблон по умолчанию для сериализации ISO недели.
+// В отличие от шаблонов пакета time это строка формата fmt, в которую
+// подставляются год и номер недели, например "%04dW%02d" для краткой формы
+const YearWeekLayout = "%04d-W%02d"
+
+// YearMonth хранит месяц года и шаблон для преобразования при сериализации
+type YearMonth struct {
+	Year   int
+	Month  time.Month
+	Layout string
+}
+
+// YearWeek хранит неделю года по ISO 8601 и шаблон для преобразования при сериализации.
+// Шаблон задаётся строкой формата fmt, как YearWeekLayout
+type YearWeek struct {
+	Year   int
+	Week   int
+	Layout string
+}
+
+// NewYearMonth создаёт объект YearMonth с шаблоном вывода по умолчанию YearMonthLayout.
+// Месяцы за пределами года переносятся: (2017, 13) = 2018-01
+func NewYearMonth(year int, month time.Month) YearMonth {
+	t := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return YearMonth{Year: t.Year(), Month: t.Month(), Layout: YearMonthLayout}
+}
+
+// ToYearMonth формирует объект YearMonth, которому принадлежит время t
+func ToYearMonth(t time.Time) YearMonth {
+	return NewYearMonth(t.Year(), t.Month())
+}
+
+// YearMonthNow возвращает объект YearMonth, соответствующий текущему месяцу
+func YearMonthNow() YearMonth {
+	return ToYearMonth(time.Now().In(defaultLocation))
+}
+
+// StringToYearMonth формирует объект YearMonth на основе строки s,
+// заданной по шаблону YearMonthLayout
+func StringToYearMonth(s string) (YearMonth, error) {
+	return parseYearMonth(YearMonthLayout, s)
+}
+
+func parseYearMonth(layout, s string) (YearMonth, error) {
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return YearMonth{}, err
+	}
+	ym := ToYearMonth(t)
+	ym.Layout = layout
+	return ym, nil
+}
+
+// fixLayout устанавливает Layout в объекте YearMonth на YearMonthLayout если он не определён
+func (ym *YearMonth) fixLayout() {
+	if ym.Layout == "" {
+		ym.Layout = YearMonthLayout
+	}
+}
+
+// AddMonths возвращает месяц, отстоящий от ym на months месяцев
+func (ym YearMonth) AddMonths(months int) YearMonth {
+	res := NewYearMonth(ym.Year, ym.Month+time.Month(months))
+	res.Layout = ym.Layout
+	return res
+}
+
+// Next возвращает следующий месяц
+func (ym YearMonth) Next() YearMonth {
+	return ym.AddMonths(1)
+}
+
+// Prev возвращает предыдущий месяц
+func (ym YearMonth) Prev() YearMonth {
+	return ym.AddMonths(-1)
+}
+
+// FirstDate возвращает первый день месяца ym
+func (ym YearMonth) FirstDate() Date {
+	return ToDate(time.Date(ym.Year, ym.Month, 1, 0, 0, 0, 0, defaultLocation))
+}
+
+// LastDate возвращает последний день месяца ym
+func (ym YearMonth) LastDate() Date {
+	return ToDate(time.Date(ym.Year, ym.Month, daysInMonth(ym.Year, ym.Month), 0, 0, 0, 0, defaultLocation))
+}
+
+// Contains возвращает true если дата d принадлежит месяцу ym, иначе false
+func (ym YearMonth) Contains(d Date) bool {
+	return d.Year() == ym.Year && d.Month() == ym.Month
+}
+
+// After возвращает true если месяц ym позднее ym1, иначе false
+func (ym YearMonth) After(ym1 YearMonth) bool {
+	return ym.Year > ym1.Year || ym.Year == ym1.Year && ym.Month > ym1.Month
+}
+
+// Before возвращает true если месяц ym ранее ym1, иначе false
+func (ym YearMonth) Before(ym1 YearMonth) bool {
+	return ym1.After(ym)
+}
+
+// Equal возвращает true если месяц ym равен ym1, иначе false
+func (ym YearMonth) Equal(ym1 YearMonth) bool {
+	return ym.Year == ym1.Year && ym.Month == ym1.Month
+}
+
+// String преобразует объект YearMonth в строку согласно шаблона в свойстве Layout
+func (ym YearMonth) String() string {
+	ym.fixLayout()
+	return time.Date(ym.Year, ym.Month, 1, 0, 0, 0, 0, time.UTC).Format(ym.Layout)
+}
+
+// UnmarshalJSON - реализует интерфейс json.Unmarshaler для объекта YearMonth
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (ym *YearMonth) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	ym.fixLayout()
+	parsed, err := parseYearMonth(ym.Layout, s)
+	if err != nil {
+		return err
+	}
+	*ym = parsed
+	return nil
+}
+
+// MarshalJSON - реализует интерфейс json.Marshaler для объекта YearMonth
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (ym YearMonth) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(ym.String())), nil
+}
+
+// EncodeValues реализует интерфейс query.Encoder для объекта YearMonth
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (ym YearMonth) EncodeValues(key string, v *url.Values) error {
+	v.Set(key, ym.String())
+	return nil
+}
+
+// MarshalText реализует интерфейс encoding.TextMarshaler для объекта YearMonth
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (ym YearMonth) MarshalText() ([]byte, error) {
+	return []byte(ym.String()), nil
+}
+
+// UnmarshalText реализует интерфейс encoding.TextUnmarshaler для объекта YearMonth
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (ym *YearMonth) UnmarshalText(text []byte) error {
+	ym.fixLayout()
+	parsed, err := parseYearMonth(ym.Layout, string(text))
+	if err != nil {
+		return err
+	}
+	*ym = parsed
+	return nil
+}
+
+// MarshalXMLAttr реализует интерфейс xml.MarshalerAttr для объекта YearMonth
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (ym YearMonth) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: ym.String()}, nil
+}
+
+// UnmarshalXMLAttr реализует интерфейс xml.UnmarshalerAttr для объекта YearMonth
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (ym *YearMonth) UnmarshalXMLAttr(attr xml.Attr) error {
+	return ym.UnmarshalText([]byte(attr.Value))
+}
+
+// UnmarshalXML реализует интерфейс xml.Unmarshaler для объекта YearMonth
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (ym *YearMonth) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	var content string
+	if err := decoder.DecodeElement(&content, &start); err != nil {
+		return err
+	}
+	ym.fixLayout()
+	parsed, err := parseYearMonth(ym.Layout, content)
+	if err != nil {
+		return err
+	}
+	*ym = parsed
+	return nil
+}
+
+// MarshalXML реализует интерфейс xml.Marshaler для объекта YearMonth
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (ym YearMonth) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	return encoder.EncodeElement(ym.String(), start)
+}
+
+// Scan преобразует значение даты в БД к типу YearMonth
+// Реализует интерфейс sql.Scanner
+func (ym *YearMonth) Scan(value interface{}) error {
+	ym.fixLayout()
+	layout := ym.Layout
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		*ym = ToYearMonth(v.In(defaultLocation))
+	case []byte, string:
+		s := fmt.Sprintf("%s", v)
+		if len(s) > len(DateLayout) {
+			s = s[:len(DateLayout)]
+		}
+		t, err := time.Parse(DateLayout, s)
+		if err != nil {
+			if t, err = time.Parse(YearMonthLayout, s); err != nil {
+				return err
+			}
+		}
+		*ym = ToYearMonth(t)
+	default:
+		return fmt.Errorf("Ошибка преобразования значения %v к типу YearMonth", value)
+	}
+	ym.Layout = layout
+	return nil
+}
+
+// Value преобразует значение типа YearMonth к значению в БД -
+// дате первого дня месяца
+// Реализует интерфейс driver.Valuer
+func (ym YearMonth) Value() (driver.Value, error) {
+	return ym.FirstDate().Value()
+}
+
+// NewYearWeek создаёт объект YearWeek с заданными годом и номером недели по ISO 8601.
+// Недели за пределами года переносятся: (2017, 53) = 2018-W01
+func NewYearWeek(year, week int) YearWeek {
+	return ToYearWeek(isoWeekStart(year, week))
+}
+
+// ToYearWeek формирует объект YearWeek, которому принадлежит время t,
+// с шаблоном вывода по умолчанию YearWeekLayout
+func ToYearWeek(t time.Time) YearWeek {
+	year, week := t.ISOWeek()
+	return YearWeek{Year: year, Week: week, Layout: YearWeekLayout}
+}
+
+// YearWeekNow возвращает объект YearWeek, соответствующий текущей неделе
+func YearWeekNow() YearWeek {
+	return ToYearWeek(time.Now().In(defaultLocation))
+}
+
+// StringToYearWeek формирует объект YearWeek на основе строки s,
+// заданной по шаблону YearWeekLayout ("2017-W05") или в краткой форме ("2017W05")
+func StringToYearWeek(s string) (YearWeek, error) {
+	parts := strings.SplitN(strings.Replace(s, "-W", "W", 1), "W", 2)
+	if len(parts) != 2 || len(parts[0]) != 4 || len(parts[1]) != 2 {
+		return YearWeek{}, fmt.Errorf("Неверный формат недели: %q", s)
+	}
+	year, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return YearWeek{}, fmt.Errorf("Неверный формат недели: %q", s)
+	}
+	week, err := strconv.Atoi(parts[1])
+	if err != nil || week < 1 || week > isoWeeksInYear(year) {
+		return YearWeek{}, fmt.Errorf("Неверный номер недели: %q", s)
+	}
+	return YearWeek{Year: year, Week: week, Layout: YearWeekLayout}, nil
+}
+
+func parseYearWeek(layout, s string) (YearWeek, error) {
+	if layout == YearWeekLayout {
+		return StringToYearWeek(s)
+	}
+	var year, week int
+	if _, err := fmt.Sscanf(s, layout, &year, &week); err != nil || fmt.Sprintf(layout, year, week) != s {
+		return YearWeek{}, fmt.Errorf("Неверный формат недели: %q", s)
+	}
+	if week < 1 || week > isoWeeksInYear(year) {
+		return YearWeek{}, fmt.Errorf("Неверный номер недели: %q", s)
+	}
+	return YearWeek{Year: year, Week: week, Layout: layout}, nil
+}
+
+// fixLayout устанавливает Layout в объекте YearWeek на YearWeekLayout если он не определён
+func (yw *YearWeek) fixLayout() {
+	if yw.Layout == "" {
+		yw.Layout = YearWeekLayout
+	}
+}
+
+// isoWeeksInYear возвращает количество недель ISO 8601 в году year
+func isoWeeksInYear(year int) int {
+	_, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return week
+}
+
+// isoWeekStart возвращает понедельник недели week года year по ISO 8601
+func isoWeekStart(year, week int) time.Time {
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, defaultLocation)
+	offset := (int(jan4.Weekday()) + 6) % 7
+	return jan4.AddDate(0, 0, (week-1)*7-offset)
+}
+
+// AddWeeks возвращает неделю, отстоящую от yw на weeks недель
+func (yw YearWeek) AddWeeks(weeks int) YearWeek {
+	res := NewYearWeek(yw.Year, yw.Week+weeks)
+	res.Layout = yw.Layout
+	return res
+}
+
+// Next возвращает следующую неделю
+func (yw YearWeek) Next() YearWeek {
+	return yw.AddWeeks(1)
+}
+
+// Prev возвращает предыдущую неделю
+func (yw YearWeek) Prev() YearWeek {
+	return yw.AddWeeks(-1)
+}
+
+// FirstDate возвращает первый день (понедельник) недели yw
+func (yw YearWeek) FirstDate() Date {
+	return ToDate(isoWeekStart(yw.Year, yw.Week))
+}
+
+// LastDate возвращает последний день (воскресенье) недели yw
+func (yw YearWeek) LastDate() Date {
+	return ToDate(isoWeekStart(yw.Year, yw.Week).AddDate(0, 0, 6))
+}
+
+// Contains возвращает true если дата d принадлежит неделе yw, иначе false
+func (yw YearWeek) Contains(d Date) bool {
+	return ToYearWeek(d.Time).Equal(yw)
+}
+
+// After возвращает true если неделя yw позднее yw1, иначе false
+func (yw YearWeek) After(yw1 YearWeek) bool {
+	return yw.Year > yw1.Year || yw.Year == yw1.Year && yw.Week > yw1.Week
+}
+
+// Before возвращает true если неделя yw ранее yw1, иначе false
+func (yw YearWeek) Before(yw1 YearWeek) bool {
+	return yw1.After(yw)
+}
+
+// Equal возвращает true если неделя yw равна yw1, иначе false
+func (yw YearWeek) Equal(yw1 YearWeek) bool {
+	return yw.Year == yw1.Year && yw.Week == yw1.Week
+}
+
+// String преобразует объект YearWeek в строку согласно шаблона в свойстве Layout
+func (yw YearWeek) String() string {
+	yw.fixLayout()
+	return fmt.Sprintf(yw.Layout, yw.Year, yw.Week)
+}
+
+// UnmarshalJSON - реализует интерфейс json.Unmarshaler для объекта YearWeek
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (yw *YearWeek) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	yw.fixLayout()
+	parsed, err := parseYearWeek(yw.Layout, s)
+	if err != nil {
+		return err
+	}
+	*yw = parsed
+	return nil
+}
+
+// MarshalJSON - реализует интерфейс json.Marshaler для объекта YearWeek
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (yw YearWeek) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(yw.String())), nil
+}
+
+// EncodeValues реализует интерфейс query.Encoder для объекта YearWeek
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (yw YearWeek) EncodeValues(key string, v *url.Values) error {
+	v.Set(key, yw.String())
+	return nil
+}
+
+// MarshalText реализует интерфейс encoding.TextMarshaler для объекта YearWeek
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (yw YearWeek) MarshalText() ([]byte, error) {
+	return []byte(yw.String()), nil
+}
+
+// UnmarshalText реализует интерфейс encoding.TextUnmarshaler для объекта YearWeek
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (yw *YearWeek) UnmarshalText(text []byte) error {
+	yw.fixLayout()
+	parsed, err := parseYearWeek(yw.Layout, string(text))
+	if err != nil {
+		return err
+	}
+	*yw = parsed
+	return nil
+}
+
+// MarshalXMLAttr реализует интерфейс xml.MarshalerAttr для объекта YearWeek
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (yw YearWeek) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: yw.String()}, nil
+}
+
+// UnmarshalXMLAttr реализует интерфейс xml.UnmarshalerAttr для объекта YearWeek
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (yw *YearWeek) UnmarshalXMLAttr(attr xml.Attr) error {
+	return yw.UnmarshalText([]byte(attr.Value))
+}
+
+// UnmarshalXML реализует интерфейс xml.Unmarshaler для объекта YearWeek
+// десериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (yw *YearWeek) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	var content string
+	if err := decoder.DecodeElement(&content, &start); err != nil {
+		return err
+	}
+	return yw.UnmarshalText([]byte(content))
+}
+
+// MarshalXML реализует интерфейс xml.Marshaler для объекта YearWeek
+// сериализация происходит с учётом шаблона, заданного в свойстве Layout
+func (yw YearWeek) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	return encoder.EncodeElement(yw.String(), start)
+}
+
+// Scan преобразует значение даты в БД к типу YearWeek
+// Реализует интерфейс sql.Scanner
+func (yw *YearWeek) Scan(value interface{}) error {
+	yw.fixLayout()
+	layout := yw.Layout
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		*yw = ToYearWeek(v.In(defaultLocation))
+	case []byte, string:
+		s := fmt.Sprintf("%s", v)
+		if len(s) > len(DateLayout) {
+			s = s[:len(DateLayout)]
+		}
+		if t, err := time.Parse(DateLayout, s); err == nil {
+			*yw = ToYearWeek(t)
+			break
+		}
+		parsed, err := parseYearWeek(layout, s)
+		if err != nil {
+			return err
+		}
+		*yw = parsed
+	default:
+		return fmt.Errorf("Ошибка преобразования значения %v к типу YearWeek", value)
+	}
+	yw.Layout = layout
+	return nil
+}
+
+// Value преобразует значение типа YearWeek к значению в БД -
+// дате первого дня (понедельника) недели
+// Реализует интерфейс driver.Valuer
+func (yw YearWeek) Value() (driver.Value, error) {
+	return yw.FirstDate().Value()
+}
@@ -0,0 +1,229 @@
+package types
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestYearMonth(t *testing.T) {
+	ym, err := StringToYearMonth("2016-12")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := ym.String(); s != "2016-12" {
+		t.Fatalf("Ожидалось 2016-12, получено %s", s)
+	}
+	if s := ym.Next().String(); s != "2017-01" {
+		t.Fatalf("Ожидалось 2017-01, получено %s", s)
+	}
+	if s := ym.Prev().String(); s != "2016-11" {
+		t.Fatalf("Ожидалось 2016-11, получено %s", s)
+	}
+	if s := ym.AddMonths(-12).String(); s != "2015-12" {
+		t.Fatalf("Ожидалось 2015-12, получено %s", s)
+	}
+	if s := NewYearMonth(2017, 14).String(); s != "2018-02" {
+		t.Fatalf("Ожидалось 2018-02, получено %s", s)
+	}
+
+	feb := NewYearMonth(2016, time.February)
+	if s := feb.FirstDate().String(); s != "2016-02-01" {
+		t.Fatalf("Ожидалось 2016-02-01, получено %s", s)
+	}
+	if s := feb.LastDate().String(); s != "2016-02-29" {
+		t.Fatalf("Ожидалось 2016-02-29, получено %s", s)
+	}
+	if !feb.Contains(feb.LastDate()) || feb.Contains(feb.LastDate().Add(0, 0, 1)) {
+		t.Fatal("Ошибка Contains")
+	}
+	if !feb.Before(ym) || feb.After(ym) || !ym.After(feb) || !feb.Equal(NewYearMonth(2016, 2)) {
+		t.Fatal("Ошибка сравнения")
+	}
+
+	ym.Layout = "01.2006"
+	if s := ym.String(); s != "12.2016" {
+		t.Fatalf("Ожидалось 12.2016, получено %s", s)
+	}
+	if _, err := StringToYearMonth("2016-13"); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+}
+
+func TestYearWeek(t *testing.T) {
+	tests := []struct {
+		s, first, last string
+	}{
+		{"2017-W28", "2017-07-10", "2017-07-16"},
+		{"2015-W53", "2015-12-28", "2016-01-03"},
+		{"2020-W01", "2019-12-30", "2020-01-05"},
+	}
+	for _, test := range tests {
+		yw, err := StringToYearWeek(test.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := yw.String(); s != test.s {
+			t.Fatalf("Ожидалось %s, получено %s", test.s, s)
+		}
+		first, last := yw.FirstDate(), yw.LastDate()
+		if first.String() != test.first || last.String() != test.last {
+			t.Fatalf("%s: ожидалось %s - %s, получено %s - %s", test.s, test.first, test.last, first, last)
+		}
+		if !yw.Contains(first) || !yw.Contains(last) || yw.Contains(last.Add(0, 0, 1)) {
+			t.Fatalf("%s: ошибка Contains", test.s)
+		}
+		if yw.Next().Prev() != yw || !yw.Next().After(yw) || !yw.Prev().Before(yw) {
+			t.Fatalf("%s: ошибка Next/Prev", test.s)
+		}
+	}
+
+	if s := NewYearWeek(2015, 53).Next().String(); s != "2016-W01" {
+		t.Fatalf("Ожидалось 2016-W01, получено %s", s)
+	}
+	if s := NewYearWeek(2017, 53).String(); s != "2018-W01" {
+		t.Fatalf("Ожидалось 2018-W01, получено %s", s)
+	}
+	if yw, err := StringToYearWeek("2017W05"); err != nil || yw != (YearWeek{2017, 5, YearWeekLayout}) {
+		t.Fatalf("Ожидалось 2017-W05, получено %v, %v", yw, err)
+	}
+	for _, s := range []string{"2017-W53", "2017-W00", "2017-28", "17-W28", "2017-Wxx"} {
+		if _, err := StringToYearWeek(s); err == nil {
+			t.Fatalf("%q: ожидалась ошибка", s)
+		}
+	}
+}
+
+func TestYearMonthYearWeekEncoding(t *testing.T) {
+	type invoice struct {
+		Month YearMonth
+		Week  YearWeek
+	}
+	inv := invoice{Month: NewYearMonth(2017, time.July), Week: NewYearWeek(2017, 28)}
+
+	b, err := json.Marshal(inv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"Month":"2017-07","Week":"2017-W28"}`; string(b) != expected {
+		t.Fatalf("Ожидалось %s, получено %s", expected, b)
+	}
+	var fromJSON invoice
+	if err := json.Unmarshal(b, &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	if fromJSON != inv {
+		t.Fatalf("Ожидалось %v, получено %v", inv, fromJSON)
+	}
+
+	b, err = xml.Marshal(inv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `<invoice><Month>2017-07</Month><Week>2017-W28</Week></invoice>`; string(b) != expected {
+		t.Fatalf("Ожидалось %s, получено %s", expected, b)
+	}
+	var fromXML invoice
+	if err := xml.Unmarshal(b, &fromXML); err != nil {
+		t.Fatal(err)
+	}
+	if fromXML != inv {
+		t.Fatalf("Ожидалось %v, получено %v", inv, fromXML)
+	}
+
+	v := url.Values{}
+	if err := inv.Month.EncodeValues("month", &v); err != nil {
+		t.Fatal(err)
+	}
+	if err := inv.Week.EncodeValues("week", &v); err != nil {
+		t.Fatal(err)
+	}
+	if s := v.Encode(); s != "month=2017-07&week=2017-W28" {
+		t.Fatalf("Ожидалось month=2017-07&week=2017-W28, получено %s", s)
+	}
+
+	type period struct {
+		Month YearMonth `xml:"month,attr"`
+		Week  YearWeek  `xml:"week,attr"`
+	}
+	b, err = xml.Marshal(period{inv.Month, inv.Week})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `<period month="2017-07" week="2017-W28"></period>`; string(b) != expected {
+		t.Fatalf("Ожидалось %s, получено %s", expected, b)
+	}
+	var fromAttr period
+	if err := xml.Unmarshal(b, &fromAttr); err != nil {
+		t.Fatal(err)
+	}
+	if fromAttr.Month != inv.Month || fromAttr.Week != inv.Week {
+		t.Fatalf("Ожидалось %v, получено %v", inv, fromAttr)
+	}
+}
+
+func TestYearWeekLayout(t *testing.T) {
+	yw := NewYearWeek(2017, 5)
+	yw.Layout = "%04dW%02d"
+	if s := yw.String(); s != "2017W05" {
+		t.Fatalf("Ожидалось 2017W05, получено %s", s)
+	}
+	if s := yw.Next().String(); s != "2017W06" {
+		t.Fatalf("Ожидалось 2017W06, получено %s", s)
+	}
+
+	parsed := YearWeek{Layout: "%d.%02d"}
+	if err := parsed.UnmarshalText([]byte("2017.28")); err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.Equal(NewYearWeek(2017, 28)) || parsed.String() != "2017.28" {
+		t.Fatalf("Ожидалось 2017.28, получено %s", parsed)
+	}
+	for _, s := range []string{"2017-W28", "2017.53", "2017.8"} {
+		if err := parsed.UnmarshalText([]byte(s)); err == nil {
+			t.Fatalf("%q: ожидалась ошибка", s)
+		}
+	}
+
+	if err := parsed.Scan("2017.30"); err != nil || parsed.String() != "2017.30" {
+		t.Fatalf("Ожидалось 2017.30, получено %s, %v", parsed, err)
+	}
+	if err := parsed.Scan("2017-07-14"); err != nil || parsed.String() != "2017.28" {
+		t.Fatalf("Ожидалось 2017.28, получено %s, %v", parsed, err)
+	}
+}
+
+func TestYearMonthYearWeekScanValueForDB(t *testing.T) {
+	d, err := StringToDate("2017-07-14")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ym YearMonth
+	for _, value := range []interface{}{d.Time, []byte("2017-07-14"), "2017-07", "2017-07-14 00:00:00"} {
+		if err := ym.Scan(value); err != nil {
+			t.Fatal(err)
+		}
+		if v, err := ym.Value(); err != nil || v != "2017-07-01" {
+			t.Fatalf("Ожидалось 2017-07-01, получено %v, %v", v, err)
+		}
+	}
+	if err := ym.Scan(42); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+
+	var yw YearWeek
+	for _, value := range []interface{}{d.Time, []byte("2017-07-14"), "2017-W28", "2017-07-14 00:00:00", []byte("2017-07-16T12:00:00Z")} {
+		if err := yw.Scan(value); err != nil {
+			t.Fatal(err)
+		}
+		if v, err := yw.Value(); err != nil || v != "2017-07-10" {
+			t.Fatalf("Ожидалось 2017-07-10, получено %v, %v", v, err)
+		}
+	}
+	if err := yw.Scan(42); err == nil {
+		t.Fatal("Ожидалась ошибка")
+	}
+}